@@ -0,0 +1,407 @@
+package httputils
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/irenicaa/go-http-utils/models"
+)
+
+const (
+	bindTagName       = "bind"
+	defaultBindSource = "form"
+	defaultMaxMemory  = 32 << 20
+)
+
+var (
+	durationType = reflect.TypeOf(time.Duration(0))
+	dateType     = reflect.TypeOf(models.Date{})
+)
+
+type bindOptions struct {
+	source       string
+	name         string
+	defaultValue string
+	hasDefault   bool
+	min          string
+	max          string
+	required     bool
+}
+
+// errSkippedSource allows a value lookup to ignore fields of some sources.
+var errSkippedSource = errors.New("skipped source")
+
+type valueLookup func(source string, name string) ([]string, error)
+
+// setFieldCheck reports whether the field got its value elsewhere,
+// so the missed value of its source doesn't make it required or default.
+type setFieldCheck func(field reflect.StructField) bool
+
+// Bind ...
+func Bind(request *http.Request, data interface{}) error {
+	return bindValues(data, makeRequestLookup(request), nil)
+}
+
+func makeRequestLookup(request *http.Request) valueLookup {
+	return func(source string, name string) ([]string, error) {
+		switch source {
+		case "query":
+			return request.URL.Query()[name], nil
+		case "form":
+			if request.Form == nil {
+				request.ParseMultipartForm(defaultMaxMemory)
+			}
+
+			return request.Form[name], nil
+		case "path":
+			value, ok := GetPathParameters(request)[name]
+			if !ok {
+				return nil, nil
+			}
+
+			return []string{value}, nil
+		case "header":
+			return request.Header.Values(name), nil
+		default:
+			return nil, fmt.Errorf("unknown source %q", source)
+		}
+	}
+}
+
+func bindValues(
+	data interface{},
+	lookup valueLookup,
+	isFieldSet setFieldCheck,
+) error {
+	dataValue := reflect.ValueOf(data)
+	if dataValue.Kind() != reflect.Ptr || dataValue.Elem().Kind() != reflect.Struct {
+		return errors.New("data should be a pointer to a struct")
+	}
+
+	var validationErr ValidationError
+	dataValue = dataValue.Elem()
+	dataType := dataValue.Type()
+	for index := 0; index < dataType.NumField(); index++ {
+		field := dataType.Field(index)
+		tag, ok := field.Tag.Lookup(bindTagName)
+		if !ok || tag == "-" {
+			continue
+		}
+		if field.PkgPath != "" {
+			return fmt.Errorf("the %s field with the bind tag is unexported", field.Name)
+		}
+
+		options, err := parseBindOptions(field.Name, tag)
+		if err != nil {
+			return fmt.Errorf(
+				"unable to parse the tag of the %s field: %w",
+				field.Name,
+				err,
+			)
+		}
+
+		values, err := lookup(options.source, options.name)
+		if errors.Is(err, errSkippedSource) {
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("unable to look up the %s field: %w", field.Name, err)
+		}
+		if len(values) == 0 || (len(values) == 1 && values[0] == "") {
+			if isFieldSet != nil && isFieldSet(field) {
+				continue
+			}
+			if options.required {
+				validationErr.AddError(options.name, ErrKeyIsMissed)
+				continue
+			}
+			if !options.hasDefault {
+				continue
+			}
+
+			values = []string{options.defaultValue}
+		}
+
+		fieldValue := dataValue.Field(index)
+
+		if err := setFieldValue(fieldValue, values, options); err != nil {
+			var fieldErr FieldError
+			if !errors.As(err, &fieldErr) {
+				return fmt.Errorf("unable to set the %s field: %w", field.Name, err)
+			}
+
+			validationErr.AddError(options.name, fieldErr.Err)
+		}
+	}
+
+	return validationErr.ErrorOrNil()
+}
+
+func parseBindOptions(fieldName string, tag string) (bindOptions, error) {
+	options := bindOptions{source: defaultBindSource, name: fieldName}
+	for _, part := range strings.Split(tag, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+
+		key, value := part, ""
+		if separatorIndex := strings.Index(part, "="); separatorIndex != -1 {
+			key, value = part[:separatorIndex], part[separatorIndex+1:]
+		}
+
+		switch key {
+		case "source":
+			options.source = value
+		case "name":
+			options.name = value
+		case "default":
+			options.defaultValue, options.hasDefault = value, true
+		case "min":
+			options.min = value
+		case "max":
+			options.max = value
+		case "required":
+			options.required = true
+		default:
+			return bindOptions{}, fmt.Errorf("unknown option %q", key)
+		}
+	}
+
+	return options, nil
+}
+
+func setFieldValue(
+	fieldValue reflect.Value,
+	values []string,
+	options bindOptions,
+) error {
+	if fieldValue.Kind() == reflect.Ptr {
+		elementValue := reflect.New(fieldValue.Type().Elem())
+		if err := setFieldValue(elementValue.Elem(), values, options); err != nil {
+			return err
+		}
+
+		fieldValue.Set(elementValue)
+		return nil
+	}
+
+	if fieldValue.Kind() == reflect.Slice {
+		sliceValue :=
+			reflect.MakeSlice(fieldValue.Type(), len(values), len(values))
+		for index, value := range values {
+			err := setSingleValue(sliceValue.Index(index), value, options)
+			if err != nil {
+				var fieldErr FieldError
+				if !errors.As(err, &fieldErr) {
+					return err
+				}
+
+				return FieldError{
+					Err: fmt.Errorf("element #%d: %w", index, fieldErr.Err),
+				}
+			}
+		}
+
+		fieldValue.Set(sliceValue)
+		return nil
+	}
+
+	return setSingleValue(fieldValue, values[0], options)
+}
+
+func setSingleValue(
+	fieldValue reflect.Value,
+	value string,
+	options bindOptions,
+) error {
+	switch {
+	case fieldValue.Type() == durationType:
+		return setDurationValue(fieldValue, value, options)
+	case fieldValue.Type() == dateType:
+		date, err := models.ParseDate(value)
+		if err != nil {
+			return FieldError{
+				Err: fmt.Errorf("unable to parse the date: %w", err),
+			}
+		}
+
+		fieldValue.Set(reflect.ValueOf(date))
+		return nil
+	}
+
+	switch fieldValue.Kind() {
+	case reflect.String:
+		fieldValue.SetString(value)
+	case reflect.Bool:
+		valueAsBool, err := strconv.ParseBool(value)
+		if err != nil {
+			return FieldError{Err: fmt.Errorf("value is incorrect: %w", err)}
+		}
+
+		fieldValue.SetBool(valueAsBool)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return setIntValue(fieldValue, value, options)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return setUintValue(fieldValue, value, options)
+	case reflect.Float32, reflect.Float64:
+		return setFloatValue(fieldValue, value, options)
+	default:
+		return fmt.Errorf("unsupported type %s", fieldValue.Type())
+	}
+
+	return nil
+}
+
+func setIntValue(
+	fieldValue reflect.Value,
+	value string,
+	options bindOptions,
+) error {
+	bitSize := fieldValue.Type().Bits()
+	parse := func(value string) (int64, error) {
+		return strconv.ParseInt(value, 10, bitSize)
+	}
+
+	valueAsInt, err := parse(value)
+	if err != nil {
+		return FieldError{Err: fmt.Errorf("value is incorrect: %w", err)}
+	}
+
+	min, max, err := parseIntLimits(options, parse)
+	if err != nil {
+		return err
+	}
+	if err := checkLimits(valueAsInt < min, valueAsInt > max); err != nil {
+		return FieldError{Err: err}
+	}
+
+	fieldValue.SetInt(valueAsInt)
+	return nil
+}
+
+func setDurationValue(
+	fieldValue reflect.Value,
+	value string,
+	options bindOptions,
+) error {
+	parse := func(value string) (int64, error) {
+		duration, err := time.ParseDuration(value)
+		return int64(duration), err
+	}
+
+	duration, err := parse(value)
+	if err != nil {
+		return FieldError{Err: fmt.Errorf("value is incorrect: %w", err)}
+	}
+
+	min, max, err := parseIntLimits(options, parse)
+	if err != nil {
+		return err
+	}
+	if err := checkLimits(duration < min, duration > max); err != nil {
+		return FieldError{Err: err}
+	}
+
+	fieldValue.SetInt(duration)
+	return nil
+}
+
+func setUintValue(
+	fieldValue reflect.Value,
+	value string,
+	options bindOptions,
+) error {
+	bitSize := fieldValue.Type().Bits()
+	valueAsUint, err := strconv.ParseUint(value, 10, bitSize)
+	if err != nil {
+		return FieldError{Err: fmt.Errorf("value is incorrect: %w", err)}
+	}
+
+	min, max := uint64(0), ^uint64(0)
+	if options.min != "" {
+		if min, err = strconv.ParseUint(options.min, 10, bitSize); err != nil {
+			return fmt.Errorf("unable to parse the minimum: %w", err)
+		}
+	}
+	if options.max != "" {
+		if max, err = strconv.ParseUint(options.max, 10, bitSize); err != nil {
+			return fmt.Errorf("unable to parse the maximum: %w", err)
+		}
+	}
+	if err := checkLimits(valueAsUint < min, valueAsUint > max); err != nil {
+		return FieldError{Err: err}
+	}
+
+	fieldValue.SetUint(valueAsUint)
+	return nil
+}
+
+func setFloatValue(
+	fieldValue reflect.Value,
+	value string,
+	options bindOptions,
+) error {
+	bitSize := fieldValue.Type().Bits()
+	valueAsFloat, err := strconv.ParseFloat(value, bitSize)
+	if err != nil {
+		return FieldError{Err: fmt.Errorf("value is incorrect: %w", err)}
+	}
+
+	if options.min != "" {
+		min, err := strconv.ParseFloat(options.min, bitSize)
+		if err != nil {
+			return fmt.Errorf("unable to parse the minimum: %w", err)
+		}
+		if err := checkLimits(valueAsFloat < min, false); err != nil {
+			return FieldError{Err: err}
+		}
+	}
+	if options.max != "" {
+		max, err := strconv.ParseFloat(options.max, bitSize)
+		if err != nil {
+			return fmt.Errorf("unable to parse the maximum: %w", err)
+		}
+		if err := checkLimits(false, valueAsFloat > max); err != nil {
+			return FieldError{Err: err}
+		}
+	}
+
+	fieldValue.SetFloat(valueAsFloat)
+	return nil
+}
+
+func parseIntLimits(
+	options bindOptions,
+	parse func(value string) (int64, error),
+) (min int64, max int64, err error) {
+	min, max = -1<<63, 1<<63-1
+	if options.min != "" {
+		if min, err = parse(options.min); err != nil {
+			return 0, 0, fmt.Errorf("unable to parse the minimum: %w", err)
+		}
+	}
+	if options.max != "" {
+		if max, err = parse(options.max); err != nil {
+			return 0, 0, fmt.Errorf("unable to parse the maximum: %w", err)
+		}
+	}
+
+	return min, max, nil
+}
+
+func checkLimits(isTooLess bool, isTooGreater bool) error {
+	if isTooLess {
+		return ErrValueTooLess
+	}
+	if isTooGreater {
+		return ErrValueTooGreater
+	}
+
+	return nil
+}
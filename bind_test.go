@@ -0,0 +1,200 @@
+package httputils
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/irenicaa/go-http-utils/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBind(t *testing.T) {
+	type testParams struct {
+		Limit    int           `bind:"source=query,name=limit,default=10,min=1,max=100"`
+		Ratio    float64       `bind:"source=query,name=ratio,max=1"`
+		Enabled  bool          `bind:"source=query,name=enabled"`
+		Tags     []string      `bind:"source=query,name=tag"`
+		Timeout  time.Duration `bind:"source=query,name=timeout,max=1m"`
+		Day      models.Date   `bind:"source=path,name=day"`
+		Token    string        `bind:"source=header,name=X-Token,required"`
+		Title    *string       `bind:"name=title"`
+		Internal string
+	}
+	type args struct {
+		request *http.Request
+		data    interface{}
+	}
+
+	title := "test"
+	tests := []struct {
+		name     string
+		args     args
+		wantData interface{}
+		wantErr  assert.ErrorAssertionFunc
+	}{
+		{
+			name: "success",
+			args: args{
+				request: func() *http.Request {
+					request := httptest.NewRequest(
+						http.MethodGet,
+						"/test?limit=23&ratio=0.5&enabled=true&tag=one&tag=two"+
+							"&timeout=5s&title=test",
+						nil,
+					)
+					request.Header.Set("X-Token", "token")
+
+					return SetPathParameters(request, PathParameters{"day": "2006-01-02"})
+				}(),
+				data: &testParams{},
+			},
+			wantData: &testParams{
+				Limit:   23,
+				Ratio:   0.5,
+				Enabled: true,
+				Tags:    []string{"one", "two"},
+				Timeout: 5 * time.Second,
+				Day: models.Date(
+					time.Date(2006, time.January, 2, 0, 0, 0, 0, time.UTC),
+				),
+				Token: "token",
+				Title: &title,
+			},
+			wantErr: assert.NoError,
+		},
+		{
+			name: "success with defaults",
+			args: args{
+				request: func() *http.Request {
+					request := httptest.NewRequest(http.MethodGet, "/test", nil)
+					request.Header.Set("X-Token", "token")
+
+					return request
+				}(),
+				data: &testParams{},
+			},
+			wantData: &testParams{Limit: 10, Token: "token"},
+			wantErr:  assert.NoError,
+		},
+		{
+			name: "error with already set values",
+			args: args{
+				request: httptest.NewRequest(http.MethodGet, "/test", nil),
+				data:    &testParams{Limit: 23, Token: "token"},
+			},
+			wantData: &testParams{Limit: 10, Token: "token"},
+			wantErr: func(t assert.TestingT, err error, msgAndArgs ...interface{}) bool {
+				return assert.ErrorIs(t, err, ErrKeyIsMissed, msgAndArgs...)
+			},
+		},
+		{
+			name: "error with several fields",
+			args: args{
+				request: httptest.NewRequest(
+					http.MethodGet,
+					"/test?limit=1000&ratio=value&enabled=true&timeout=1h",
+					nil,
+				),
+				data: &testParams{},
+			},
+			wantData: &testParams{Enabled: true},
+			wantErr: func(t assert.TestingT, err error, msgAndArgs ...interface{}) bool {
+				var validationErr *ValidationError
+				if !assert.True(t, errors.As(err, &validationErr), msgAndArgs...) {
+					return false
+				}
+
+				var fields []string
+				for _, field := range validationErr.Fields {
+					fields = append(fields, field.Field)
+				}
+
+				return assert.Equal(
+					t,
+					[]string{"limit", "ratio", "timeout", "X-Token"},
+					fields,
+					msgAndArgs...,
+				) && assert.ErrorIs(t, err, ErrKeyIsMissed, msgAndArgs...)
+			},
+		},
+		{
+			name: "error with an incorrect slice element",
+			args: args{
+				request: httptest.NewRequest(http.MethodGet, "/test?id=1&id=two", nil),
+				data: &struct {
+					IDs []int `bind:"source=query,name=id"`
+				}{},
+			},
+			wantData: &struct {
+				IDs []int `bind:"source=query,name=id"`
+			}{},
+			wantErr: assert.Error,
+		},
+		{
+			name: "error with an incorrect tag",
+			args: args{
+				request: httptest.NewRequest(http.MethodGet, "/test?limit=23", nil),
+				data: &struct {
+					Limit int `bind:"source=query,name=limit,unknown"`
+				}{},
+			},
+			wantData: &struct {
+				Limit int `bind:"source=query,name=limit,unknown"`
+			}{},
+			wantErr: assert.Error,
+		},
+		{
+			name: "error with an unsupported type",
+			args: args{
+				request: httptest.NewRequest(http.MethodGet, "/test?data=23", nil),
+				data: &struct {
+					Data map[string]string `bind:"source=query,name=data"`
+				}{},
+			},
+			wantData: &struct {
+				Data map[string]string `bind:"source=query,name=data"`
+			}{},
+			wantErr: assert.Error,
+		},
+		{
+			name: "error with an unexported field",
+			args: args{
+				request: httptest.NewRequest(http.MethodGet, "/test?limit=23", nil),
+				data: &struct {
+					limit int `bind:"source=query,name=limit"`
+				}{},
+			},
+			wantData: &struct {
+				limit int `bind:"source=query,name=limit"`
+			}{},
+			wantErr: func(t assert.TestingT, err error, msgAndArgs ...interface{}) bool {
+				return assert.EqualError(
+					t,
+					err,
+					"the limit field with the bind tag is unexported",
+					msgAndArgs...,
+				)
+			},
+		},
+		{
+			name: "error with a non-pointer",
+			args: args{
+				request: httptest.NewRequest(http.MethodGet, "/test", nil),
+				data:    testParams{},
+			},
+			wantData: testParams{},
+			wantErr:  assert.Error,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := Bind(tt.args.request, tt.args.data)
+
+			assert.Equal(t, tt.wantData, tt.args.data)
+			tt.wantErr(t, err)
+		})
+	}
+}
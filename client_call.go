@@ -0,0 +1,103 @@
+package httputils
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"time"
+)
+
+// ...
+var (
+	ErrDeadlineExceeded = fmt.Errorf(
+		"request deadline was exceeded: %w",
+		context.DeadlineExceeded,
+	)
+	ErrRequestCancelled = fmt.Errorf("request was cancelled: %w", context.Canceled)
+	ErrTransportFailure = errors.New("transport failure")
+)
+
+// CallOptions ...
+//
+// A zero timeout means the call is limited by its context only.
+type CallOptions struct {
+	Timeout time.Duration
+}
+
+// DoStreamingRequest ...
+//
+// It sends the request with the call timeout and returns the response
+// if its status is 200 OK; closing its body releases the timeout.
+func DoStreamingRequest(
+	httpClient HTTPClient,
+	request *http.Request,
+	options CallOptions,
+) (*http.Response, error) {
+	ctx, cancel := withCallTimeout(request.Context(), options.Timeout)
+	request = request.WithContext(ctx)
+
+	response, err := httpClient.Do(request)
+	if err != nil {
+		// the error is classified before the cancellation affects the context
+		defer cancel()
+		return nil,
+			fmt.Errorf("unable to send the request: %w", makeCallError(ctx, err))
+	}
+
+	if response.StatusCode != http.StatusOK {
+		defer cancel()
+		defer response.Body.Close()
+
+		responseBytes, err := ioutil.ReadAll(response.Body)
+		if err != nil {
+			return nil, fmt.Errorf(
+				"unable to read the request body: %w",
+				makeCallError(ctx, err),
+			)
+		}
+
+		return nil, makeResponseError(response, responseBytes)
+	}
+
+	response.Body = cancellingReadCloser{ReadCloser: response.Body, cancel: cancel}
+	return response, nil
+}
+
+func withCallTimeout(ctx context.Context, timeout time.Duration) (
+	context.Context,
+	context.CancelFunc,
+) {
+	if timeout <= 0 {
+		return ctx, func() {}
+	}
+
+	return context.WithTimeout(ctx, timeout)
+}
+
+func makeCallError(ctx context.Context, err error) error {
+	var timeoutErr interface{ Timeout() bool }
+	switch {
+	case errors.Is(ctx.Err(), context.DeadlineExceeded),
+		errors.Is(err, context.DeadlineExceeded),
+		errors.As(err, &timeoutErr) && timeoutErr.Timeout():
+		return fmt.Errorf("%w: %s", ErrDeadlineExceeded, err)
+	case errors.Is(ctx.Err(), context.Canceled), errors.Is(err, context.Canceled):
+		return fmt.Errorf("%w: %s", ErrRequestCancelled, err)
+	default:
+		return fmt.Errorf("%w: %s", ErrTransportFailure, err)
+	}
+}
+
+type cancellingReadCloser struct {
+	io.ReadCloser
+
+	cancel context.CancelFunc
+}
+
+func (reader cancellingReadCloser) Close() error {
+	defer reader.cancel()
+	return reader.ReadCloser.Close()
+}
@@ -0,0 +1,238 @@
+package httputils
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"testing/iotest"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type timeoutError struct{}
+
+func (err timeoutError) Error() string { return "timeout" }
+func (err timeoutError) Timeout() bool { return true }
+
+func TestDoStreamingRequest(t *testing.T) {
+	type args struct {
+		response *http.Response
+		err      error
+	}
+
+	tests := []struct {
+		name     string
+		args     args
+		wantBody string
+		wantErr  assert.ErrorAssertionFunc
+	}{
+		{
+			name: "success",
+			args: args{
+				response: &http.Response{
+					StatusCode: http.StatusOK,
+					Body:       ioutil.NopCloser(strings.NewReader("test")),
+				},
+				err: nil,
+			},
+			wantBody: "test",
+			wantErr:  assert.NoError,
+		},
+		{
+			name: "error with the transport",
+			args: args{
+				response: nil,
+				err:      iotest.ErrTimeout,
+			},
+			wantBody: "",
+			wantErr: func(t assert.TestingT, err error, msgAndArgs ...interface{}) bool {
+				return assert.ErrorIs(t, err, ErrTransportFailure, msgAndArgs...)
+			},
+		},
+		{
+			name: "error with the response status",
+			args: args{
+				response: &http.Response{
+					StatusCode: http.StatusUnauthorized,
+					Body:       ioutil.NopCloser(strings.NewReader("error")),
+				},
+				err: nil,
+			},
+			wantBody: "",
+			wantErr: func(t assert.TestingT, err error, msgAndArgs ...interface{}) bool {
+				return assert.Equal(t, ResponseError{
+					StatusCode: http.StatusUnauthorized,
+					Body:       []byte("error"),
+				}, err, msgAndArgs...)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var requestCtx context.Context
+			httpClient := &MockHTTPClient{}
+			httpClient.InnerMock.
+				On("Do", mock.AnythingOfType("*http.Request")).
+				Run(func(args mock.Arguments) {
+					requestCtx = args.Get(0).(*http.Request).Context()
+				}).
+				Return(tt.args.response, tt.args.err).
+				Times(1)
+
+			request := httptest.NewRequest(http.MethodGet, "http://example.com/", nil)
+			response, err := DoStreamingRequest(
+				httpClient,
+				request,
+				CallOptions{Timeout: time.Minute},
+			)
+
+			httpClient.InnerMock.AssertExpectations(t)
+			tt.wantErr(t, err)
+			if err != nil {
+				assert.Equal(t, context.Canceled, requestCtx.Err())
+				return
+			}
+
+			body, err := ioutil.ReadAll(response.Body)
+			require.NoError(t, err)
+			require.NoError(t, requestCtx.Err())
+
+			err = response.Body.Close()
+
+			assert.Equal(t, tt.wantBody, string(body))
+			assert.Equal(t, context.Canceled, requestCtx.Err())
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func Test_withCallTimeout(t *testing.T) {
+	type args struct {
+		ctx     context.Context
+		timeout time.Duration
+	}
+
+	tests := []struct {
+		name         string
+		args         args
+		wantDeadline bool
+	}{
+		{
+			name: "without the timeout",
+			args: args{
+				ctx:     context.Background(),
+				timeout: 0,
+			},
+			wantDeadline: false,
+		},
+		{
+			name: "with the timeout",
+			args: args{
+				ctx:     context.Background(),
+				timeout: time.Minute,
+			},
+			wantDeadline: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotCtx, gotCancel := withCallTimeout(tt.args.ctx, tt.args.timeout)
+			defer gotCancel()
+
+			_, gotDeadline := gotCtx.Deadline()
+			assert.Equal(t, tt.wantDeadline, gotDeadline)
+		})
+	}
+}
+
+func Test_makeCallError(t *testing.T) {
+	type args struct {
+		ctx context.Context
+		err error
+	}
+
+	tests := []struct {
+		name        string
+		args        args
+		wantErr     error
+		wantMessage string
+	}{
+		{
+			name: "with the exceeded deadline of the context",
+			args: args{
+				ctx: func() context.Context {
+					ctx, cancel := context.WithDeadline(context.Background(), time.Time{})
+					defer cancel()
+
+					return ctx
+				}(),
+				err: errors.New("dummy"),
+			},
+			wantErr: ErrDeadlineExceeded,
+			wantMessage: "request deadline was exceeded: " +
+				"context deadline exceeded: dummy",
+		},
+		{
+			name: "with the timeout error",
+			args: args{
+				ctx: context.Background(),
+				err: &url.Error{Op: "Get", URL: "http://example.com/", Err: timeoutError{}},
+			},
+			wantErr: ErrDeadlineExceeded,
+			wantMessage: "request deadline was exceeded: " +
+				`context deadline exceeded: Get "http://example.com/": timeout`,
+		},
+		{
+			name: "with the cancelled context",
+			args: args{
+				ctx: func() context.Context {
+					ctx, cancel := context.WithCancel(context.Background())
+					cancel()
+
+					return ctx
+				}(),
+				err: errors.New("dummy"),
+			},
+			wantErr:     ErrRequestCancelled,
+			wantMessage: "request was cancelled: context canceled: dummy",
+		},
+		{
+			name: "with the transport failure",
+			args: args{
+				ctx: context.Background(),
+				err: iotest.ErrTimeout,
+			},
+			wantErr:     ErrTransportFailure,
+			wantMessage: "transport failure: timeout",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := makeCallError(tt.args.ctx, tt.args.err)
+
+			assert.True(t, errors.Is(err, tt.wantErr))
+			assert.EqualError(t, err, tt.wantMessage)
+		})
+	}
+}
+
+func Test_cancellingReadCloser(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	reader := cancellingReadCloser{
+		ReadCloser: ioutil.NopCloser(strings.NewReader("test")),
+		cancel:     cancel,
+	}
+
+	err := reader.Close()
+
+	assert.Equal(t, context.Canceled, ctx.Err())
+	assert.NoError(t, err)
+}
@@ -0,0 +1,391 @@
+package clients
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	httputils "github.com/irenicaa/go-http-utils"
+)
+
+// ...
+const (
+	DefaultConsecutiveFailures = 5
+	DefaultMinRequests         = 10
+	DefaultCircuitWindow       = time.Minute
+	DefaultOpenTimeout         = 30 * time.Second
+	DefaultHalfOpenRequests    = 1
+)
+
+const circuitBucketCount = 10
+
+// shorter windows are extended to it, so each bucket lasts a millisecond at least
+const minCircuitWindow = circuitBucketCount * time.Millisecond
+
+// DefaultFailureStatusClasses ...
+var DefaultFailureStatusClasses = []int{5}
+
+// ErrCircuitIsOpen ...
+var ErrCircuitIsOpen = errors.New("circuit is open")
+
+// CircuitState ...
+type CircuitState int
+
+// ...
+const (
+	ClosedCircuitState CircuitState = iota
+	OpenCircuitState
+	HalfOpenCircuitState
+)
+
+// String ...
+func (state CircuitState) String() string {
+	switch state {
+	case ClosedCircuitState:
+		return "closed"
+	case OpenCircuitState:
+		return "open"
+	case HalfOpenCircuitState:
+		return "half-open"
+	default:
+		return fmt.Sprintf("CircuitState(%d)", int(state))
+	}
+}
+
+// CircuitOpenError ...
+type CircuitOpenError struct {
+	Host    string
+	RetryAt time.Time
+}
+
+// Error ...
+func (err CircuitOpenError) Error() string {
+	return fmt.Sprintf("%s for the %s host", ErrCircuitIsOpen, err.Host)
+}
+
+// Unwrap ...
+func (err CircuitOpenError) Unwrap() error {
+	return ErrCircuitIsOpen
+}
+
+// CircuitBreakerOptions ...
+//
+// The circuit trips after the number of consecutive failures
+// or when the failure ratio over the rolling window is reached;
+// either condition can be disabled with a zero value,
+// but the consecutive failures are used by default if both are.
+// A non-positive window is replaced with the default one.
+// Responses with the status classes (e.g. 5 for 5xx) count as failures.
+// An open circuit lets the half-open requests through
+// after the open timeout; it's closed if all of them succeed.
+type CircuitBreakerOptions struct {
+	ConsecutiveFailures  int
+	FailureRatio         float64
+	MinRequests          int
+	Window               time.Duration
+	OpenTimeout          time.Duration
+	HalfOpenRequests     int
+	FailureStatusClasses []int
+	OnStateChange        func(host string, from CircuitState, to CircuitState)
+}
+
+// CircuitBreakerClient ...
+//
+// It keeps a separate circuit for each host.
+type CircuitBreakerClient struct {
+	HTTPClient httputils.HTTPClient
+	Options    CircuitBreakerOptions
+	Clock      func() time.Time
+
+	lock     sync.Mutex
+	circuits map[string]*circuit
+}
+
+// NewCircuitBreakerClient ...
+func NewCircuitBreakerClient(
+	httpClient httputils.HTTPClient,
+	options CircuitBreakerOptions,
+) *CircuitBreakerClient {
+	if options.ConsecutiveFailures == 0 && options.FailureRatio == 0 {
+		options.ConsecutiveFailures = DefaultConsecutiveFailures
+	}
+	if options.MinRequests == 0 {
+		options.MinRequests = DefaultMinRequests
+	}
+	if options.Window <= 0 {
+		options.Window = DefaultCircuitWindow
+	} else if options.Window < minCircuitWindow {
+		options.Window = minCircuitWindow
+	}
+	if options.OpenTimeout == 0 {
+		options.OpenTimeout = DefaultOpenTimeout
+	}
+	if options.HalfOpenRequests == 0 {
+		options.HalfOpenRequests = DefaultHalfOpenRequests
+	}
+	if options.FailureStatusClasses == nil {
+		options.FailureStatusClasses = DefaultFailureStatusClasses
+	}
+
+	return &CircuitBreakerClient{
+		HTTPClient: httpClient,
+		Options:    options,
+		Clock:      time.Now,
+		circuits:   map[string]*circuit{},
+	}
+}
+
+// State ...
+func (client *CircuitBreakerClient) State(host string) CircuitState {
+	client.lock.Lock()
+	defer client.lock.Unlock()
+
+	circuit, ok := client.circuits[host]
+	if !ok {
+		return ClosedCircuitState
+	}
+
+	return circuit.state
+}
+
+// Do ...
+func (client *CircuitBreakerClient) Do(request *http.Request) (
+	*http.Response,
+	error,
+) {
+	host := request.URL.Host
+	generation, err := client.acquire(host)
+	if err != nil {
+		return nil, err
+	}
+
+	response, err := client.HTTPClient.Do(request)
+
+	// a cancellation by the caller says nothing about the host
+	isCancelled := errors.Is(request.Context().Err(), context.Canceled)
+	isFailure := err != nil || client.isFailureStatus(response.StatusCode)
+	client.release(host, generation, isCancelled, isFailure)
+
+	return response, err
+}
+
+func (client *CircuitBreakerClient) acquire(host string) (
+	generation int,
+	err error,
+) {
+	var transitions []circuitTransition
+	defer func() { client.notify(host, transitions) }()
+
+	client.lock.Lock()
+	defer client.lock.Unlock()
+
+	circuit, ok := client.circuits[host]
+	if !ok {
+		circuit = newCircuit(client.Options.Window)
+		client.circuits[host] = circuit
+	}
+
+	now := client.Clock()
+	if circuit.state == OpenCircuitState {
+		retryAt := circuit.openedAt.Add(client.Options.OpenTimeout)
+		if now.Before(retryAt) {
+			return 0, CircuitOpenError{Host: host, RetryAt: retryAt}
+		}
+
+		transitions =
+			append(transitions, circuit.setState(HalfOpenCircuitState, now))
+	}
+	if circuit.state == HalfOpenCircuitState {
+		if circuit.halfOpenRequests >= client.Options.HalfOpenRequests {
+			retryAt := now.Add(client.Options.OpenTimeout)
+			return 0, CircuitOpenError{Host: host, RetryAt: retryAt}
+		}
+
+		circuit.halfOpenRequests++
+	}
+
+	return circuit.generation, nil
+}
+
+func (client *CircuitBreakerClient) release(
+	host string,
+	generation int,
+	isCancelled bool,
+	isFailure bool,
+) {
+	var transitions []circuitTransition
+	defer func() { client.notify(host, transitions) }()
+
+	client.lock.Lock()
+	defer client.lock.Unlock()
+
+	// the outcome of a request that started in the previous state is ignored
+	circuit := client.circuits[host]
+	if circuit.generation != generation {
+		return
+	}
+
+	now := client.Clock()
+	switch circuit.state {
+	case HalfOpenCircuitState:
+		circuit.halfOpenRequests--
+		if isCancelled {
+			return
+		}
+
+		if isFailure {
+			transitions =
+				append(transitions, circuit.setState(OpenCircuitState, now))
+			return
+		}
+
+		circuit.halfOpenSuccesses++
+		if circuit.halfOpenSuccesses >= client.Options.HalfOpenRequests {
+			transitions =
+				append(transitions, circuit.setState(ClosedCircuitState, now))
+		}
+	case ClosedCircuitState:
+		if isCancelled {
+			return
+		}
+
+		circuit.record(now, isFailure)
+		if client.shouldTrip(circuit, now) {
+			transitions =
+				append(transitions, circuit.setState(OpenCircuitState, now))
+		}
+	}
+}
+
+func (client *CircuitBreakerClient) shouldTrip(
+	circuit *circuit,
+	now time.Time,
+) bool {
+	if client.Options.ConsecutiveFailures > 0 &&
+		circuit.consecutiveFailures >= client.Options.ConsecutiveFailures {
+		return true
+	}
+
+	if client.Options.FailureRatio > 0 {
+		successes, failures := circuit.count(now)
+		total := successes + failures
+		if total >= client.Options.MinRequests &&
+			float64(failures)/float64(total) >= client.Options.FailureRatio {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (client *CircuitBreakerClient) isFailureStatus(status int) bool {
+	for _, statusClass := range client.Options.FailureStatusClasses {
+		if status/100 == statusClass {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (client *CircuitBreakerClient) notify(
+	host string,
+	transitions []circuitTransition,
+) {
+	if client.Options.OnStateChange == nil {
+		return
+	}
+
+	for _, transition := range transitions {
+		client.Options.OnStateChange(host, transition.from, transition.to)
+	}
+}
+
+type circuitTransition struct {
+	from CircuitState
+	to   CircuitState
+}
+
+type circuitBucket struct {
+	start     time.Time
+	successes int
+	failures  int
+}
+
+type circuit struct {
+	state               CircuitState
+	generation          int
+	openedAt            time.Time
+	consecutiveFailures int
+	halfOpenRequests    int
+	halfOpenSuccesses   int
+	window              time.Duration
+	buckets             []circuitBucket
+}
+
+func newCircuit(window time.Duration) *circuit {
+	return &circuit{
+		window:  window,
+		buckets: make([]circuitBucket, circuitBucketCount),
+	}
+}
+
+func (circuit *circuit) setState(
+	state CircuitState,
+	now time.Time,
+) circuitTransition {
+	transition := circuitTransition{from: circuit.state, to: state}
+	circuit.state = state
+	circuit.generation++
+	circuit.halfOpenRequests = 0
+	circuit.halfOpenSuccesses = 0
+	switch state {
+	case OpenCircuitState:
+		circuit.openedAt = now
+	case ClosedCircuitState:
+		circuit.consecutiveFailures = 0
+		circuit.buckets = make([]circuitBucket, circuitBucketCount)
+	}
+
+	return transition
+}
+
+func (circuit *circuit) record(now time.Time, isFailure bool) {
+	if isFailure {
+		circuit.consecutiveFailures++
+	} else {
+		circuit.consecutiveFailures = 0
+	}
+
+	bucketDuration := circuit.window / circuitBucketCount
+	bucketStart := now.Truncate(bucketDuration)
+	bucketIndex := int(bucketStart.UnixNano()/int64(bucketDuration)) %
+		circuitBucketCount
+	bucket := &circuit.buckets[bucketIndex]
+	if !bucket.start.Equal(bucketStart) {
+		*bucket = circuitBucket{start: bucketStart}
+	}
+
+	if isFailure {
+		bucket.failures++
+	} else {
+		bucket.successes++
+	}
+}
+
+func (circuit *circuit) count(now time.Time) (successes int, failures int) {
+	windowStart := now.Add(-circuit.window)
+	for _, bucket := range circuit.buckets {
+		if !bucket.start.After(windowStart) {
+			continue
+		}
+
+		successes += bucket.successes
+		failures += bucket.failures
+	}
+
+	return successes, failures
+}
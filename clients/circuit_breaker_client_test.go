@@ -0,0 +1,409 @@
+package clients
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"testing/iotest"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCircuitState_String(t *testing.T) {
+	tests := []struct {
+		name  string
+		state CircuitState
+		want  string
+	}{
+		{
+			name:  "closed",
+			state: ClosedCircuitState,
+			want:  "closed",
+		},
+		{
+			name:  "open",
+			state: OpenCircuitState,
+			want:  "open",
+		},
+		{
+			name:  "half-open",
+			state: HalfOpenCircuitState,
+			want:  "half-open",
+		},
+		{
+			name:  "unknown",
+			state: CircuitState(23),
+			want:  "CircuitState(23)",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.state.String()
+
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestCircuitOpenError(t *testing.T) {
+	err := CircuitOpenError{
+		Host:    "example.com",
+		RetryAt: time.Date(2021, time.January, 15, 4, 16, 50, 0, time.UTC),
+	}
+
+	assert.EqualError(t, err, "circuit is open for the example.com host")
+	assert.True(t, errors.Is(err, ErrCircuitIsOpen))
+}
+
+func TestNewCircuitBreakerClient(t *testing.T) {
+	tests := []struct {
+		name       string
+		window     time.Duration
+		wantWindow time.Duration
+	}{
+		{
+			name:       "with the zero window",
+			window:     0,
+			wantWindow: DefaultCircuitWindow,
+		},
+		{
+			name:       "with the negative window",
+			window:     -time.Second,
+			wantWindow: DefaultCircuitWindow,
+		},
+		{
+			name:       "with the too small window",
+			window:     5 * time.Nanosecond,
+			wantWindow: minCircuitWindow,
+		},
+		{
+			name:       "with the custom window",
+			window:     10 * time.Second,
+			wantWindow: 10 * time.Second,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			httpClient := &MockHTTPClient{}
+			got := NewCircuitBreakerClient(
+				httpClient,
+				CircuitBreakerOptions{Window: tt.window},
+			)
+
+			assert.Equal(t, httpClient, got.HTTPClient)
+			assert.Equal(t, CircuitBreakerOptions{
+				ConsecutiveFailures:  DefaultConsecutiveFailures,
+				MinRequests:          DefaultMinRequests,
+				Window:               tt.wantWindow,
+				OpenTimeout:          DefaultOpenTimeout,
+				HalfOpenRequests:     DefaultHalfOpenRequests,
+				FailureStatusClasses: DefaultFailureStatusClasses,
+			}, got.Options)
+			assert.NotNil(t, got.Clock)
+		})
+	}
+}
+
+func TestCircuitBreakerClient_Do(t *testing.T) {
+	type step struct {
+		host        string
+		advance     time.Duration
+		isCancelled bool
+		status      int
+		err         error
+		wantErr     error
+		wantState   CircuitState
+	}
+
+	tests := []struct {
+		name            string
+		options         CircuitBreakerOptions
+		steps           []step
+		wantTransitions []string
+	}{
+		{
+			name: "with consecutive failures and the successful probe",
+			options: CircuitBreakerOptions{
+				ConsecutiveFailures: 2,
+				OpenTimeout:         time.Minute,
+			},
+			steps: []step{
+				{status: http.StatusInternalServerError, wantState: ClosedCircuitState},
+				{status: http.StatusOK, wantState: ClosedCircuitState},
+				{status: http.StatusBadGateway, wantState: ClosedCircuitState},
+				{status: http.StatusServiceUnavailable, wantState: OpenCircuitState},
+				{
+					advance:   30 * time.Second,
+					wantErr:   ErrCircuitIsOpen,
+					wantState: OpenCircuitState,
+				},
+				{
+					advance:   30 * time.Second,
+					status:    http.StatusOK,
+					wantState: ClosedCircuitState,
+				},
+				{status: http.StatusBadGateway, wantState: ClosedCircuitState},
+			},
+			wantTransitions: []string{
+				"example.com: closed -> open",
+				"example.com: open -> half-open",
+				"example.com: half-open -> closed",
+			},
+		},
+		{
+			name: "with the failed probe",
+			options: CircuitBreakerOptions{
+				ConsecutiveFailures: 1,
+				OpenTimeout:         time.Minute,
+			},
+			steps: []step{
+				{err: iotest.ErrTimeout, wantState: OpenCircuitState},
+				{
+					advance:   time.Minute,
+					status:    http.StatusGatewayTimeout,
+					wantState: OpenCircuitState,
+				},
+				{wantErr: ErrCircuitIsOpen, wantState: OpenCircuitState},
+			},
+			wantTransitions: []string{
+				"example.com: closed -> open",
+				"example.com: open -> half-open",
+				"example.com: half-open -> open",
+			},
+		},
+		{
+			name: "with the failure ratio",
+			options: CircuitBreakerOptions{
+				FailureRatio: 0.5,
+				MinRequests:  4,
+			},
+			steps: []step{
+				{status: http.StatusInternalServerError, wantState: ClosedCircuitState},
+				{status: http.StatusInternalServerError, wantState: ClosedCircuitState},
+				{status: http.StatusOK, wantState: ClosedCircuitState},
+				{status: http.StatusOK, wantState: OpenCircuitState},
+				{wantErr: ErrCircuitIsOpen, wantState: OpenCircuitState},
+			},
+			wantTransitions: []string{"example.com: closed -> open"},
+		},
+		{
+			name: "with the failure ratio over the rolling window",
+			options: CircuitBreakerOptions{
+				FailureRatio: 0.5,
+				MinRequests:  2,
+				Window:       10 * time.Second,
+			},
+			steps: []step{
+				{status: http.StatusInternalServerError, wantState: ClosedCircuitState},
+				{
+					advance:   20 * time.Second,
+					status:    http.StatusOK,
+					wantState: ClosedCircuitState,
+				},
+				{status: http.StatusOK, wantState: ClosedCircuitState},
+				{status: http.StatusInternalServerError, wantState: ClosedCircuitState},
+			},
+			wantTransitions: nil,
+		},
+		{
+			name: "with the too small window",
+			options: CircuitBreakerOptions{
+				FailureRatio: 0.5,
+				MinRequests:  2,
+				Window:       time.Nanosecond,
+			},
+			steps: []step{
+				{status: http.StatusInternalServerError, wantState: ClosedCircuitState},
+				{status: http.StatusInternalServerError, wantState: OpenCircuitState},
+			},
+			wantTransitions: []string{"example.com: closed -> open"},
+		},
+		{
+			name: "with the failure status classes",
+			options: CircuitBreakerOptions{
+				ConsecutiveFailures:  2,
+				FailureStatusClasses: []int{4, 5},
+			},
+			steps: []step{
+				{status: http.StatusNotFound, wantState: ClosedCircuitState},
+				{status: http.StatusTooManyRequests, wantState: OpenCircuitState},
+			},
+			wantTransitions: []string{"example.com: closed -> open"},
+		},
+		{
+			name: "without the failure status classes",
+			options: CircuitBreakerOptions{
+				ConsecutiveFailures: 2,
+			},
+			steps: []step{
+				{status: http.StatusNotFound, wantState: ClosedCircuitState},
+				{status: http.StatusTooManyRequests, wantState: ClosedCircuitState},
+			},
+			wantTransitions: nil,
+		},
+		{
+			name: "with cancelled requests",
+			options: CircuitBreakerOptions{
+				ConsecutiveFailures: 2,
+			},
+			steps: []step{
+				{err: iotest.ErrTimeout, wantState: ClosedCircuitState},
+				{
+					isCancelled: true,
+					err:         context.Canceled,
+					wantState:   ClosedCircuitState,
+				},
+				{err: iotest.ErrTimeout, wantState: OpenCircuitState},
+			},
+			wantTransitions: []string{"example.com: closed -> open"},
+		},
+		{
+			name: "with several hosts",
+			options: CircuitBreakerOptions{
+				ConsecutiveFailures: 1,
+			},
+			steps: []step{
+				{
+					host:      "one.example.com",
+					err:       iotest.ErrTimeout,
+					wantState: OpenCircuitState,
+				},
+				{
+					host:      "one.example.com",
+					wantErr:   ErrCircuitIsOpen,
+					wantState: OpenCircuitState,
+				},
+				{
+					host:      "two.example.com",
+					status:    http.StatusOK,
+					wantState: ClosedCircuitState,
+				},
+			},
+			wantTransitions: []string{"one.example.com: closed -> open"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			httpClient := &MockHTTPClient{}
+			for _, step := range tt.steps {
+				if step.wantErr != nil {
+					continue
+				}
+
+				var response *http.Response
+				if step.err == nil {
+					response = &http.Response{
+						StatusCode: step.status,
+						Body:       ioutil.NopCloser(strings.NewReader("test")),
+					}
+				}
+
+				httpClient.InnerMock.
+					On("Do", mock.AnythingOfType("*http.Request")).
+					Return(response, step.err).
+					Times(1)
+			}
+
+			var gotTransitions []string
+			options := tt.options
+			options.OnStateChange = func(
+				host string,
+				from CircuitState,
+				to CircuitState,
+			) {
+				transition := fmt.Sprintf("%s: %s -> %s", host, from, to)
+				gotTransitions = append(gotTransitions, transition)
+			}
+
+			now := time.Date(2021, time.January, 15, 4, 16, 50, 0, time.UTC)
+			client := NewCircuitBreakerClient(httpClient, options)
+			client.Clock = func() time.Time { return now }
+
+			for index, step := range tt.steps {
+				now = now.Add(step.advance)
+
+				host := step.host
+				if host == "" {
+					host = "example.com"
+				}
+
+				request := httptest.NewRequest(http.MethodGet, "http://"+host+"/", nil)
+				if step.isCancelled {
+					ctx, cancel := context.WithCancel(context.Background())
+					cancel()
+
+					request = request.WithContext(ctx)
+				}
+
+				response, err := client.Do(request)
+				if step.wantErr != nil {
+					require.Nil(t, response, "step #%d", index)
+					require.True(t, errors.Is(err, step.wantErr), "step #%d", index)
+				} else {
+					require.Equal(t, step.err, err, "step #%d", index)
+				}
+				require.Equal(t, step.wantState, client.State(host), "step #%d", index)
+			}
+
+			httpClient.InnerMock.AssertExpectations(t)
+			assert.Equal(t, tt.wantTransitions, gotTransitions)
+		})
+	}
+}
+
+func TestCircuitBreakerClient_Do_withHalfOpenRequests(t *testing.T) {
+	probeStarted := make(chan struct{})
+	probeFinished := make(chan struct{})
+	httpClient := &MockHTTPClient{}
+	httpClient.InnerMock.
+		On("Do", mock.AnythingOfType("*http.Request")).
+		Return((*http.Response)(nil), iotest.ErrTimeout).
+		Times(1)
+	httpClient.InnerMock.
+		On("Do", mock.AnythingOfType("*http.Request")).
+		Run(func(mock.Arguments) {
+			close(probeStarted)
+			<-probeFinished
+		}).
+		Return(&http.Response{
+			StatusCode: http.StatusOK,
+			Body:       ioutil.NopCloser(strings.NewReader("test")),
+		}, nil).
+		Times(1)
+
+	now := time.Date(2021, time.January, 15, 4, 16, 50, 0, time.UTC)
+	client := NewCircuitBreakerClient(
+		httpClient,
+		CircuitBreakerOptions{ConsecutiveFailures: 1, OpenTimeout: time.Minute},
+	)
+	client.Clock = func() time.Time { return now }
+
+	request := httptest.NewRequest(http.MethodGet, "http://example.com/", nil)
+	_, err := client.Do(request)
+	require.Equal(t, iotest.ErrTimeout, err)
+
+	now = now.Add(time.Minute)
+	probeErrs := make(chan error)
+	go func() {
+		_, err := client.Do(request)
+		probeErrs <- err
+	}()
+	<-probeStarted
+
+	_, err = client.Do(request)
+	assert.True(t, errors.Is(err, ErrCircuitIsOpen))
+	assert.Equal(t, HalfOpenCircuitState, client.State("example.com"))
+
+	close(probeFinished)
+	assert.NoError(t, <-probeErrs)
+	assert.Equal(t, ClosedCircuitState, client.State("example.com"))
+	httpClient.InnerMock.AssertExpectations(t)
+}
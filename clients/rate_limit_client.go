@@ -0,0 +1,338 @@
+package clients
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	httputils "github.com/irenicaa/go-http-utils"
+)
+
+// values of the reset headers greater than it are treated as Unix time
+const minUnixTimeReset = 1000000000
+
+// DefaultMaxAdaptiveDelay ...
+const DefaultMaxAdaptiveDelay = time.Minute
+
+// RateLimit ...
+//
+// A zero rate or maximal number of in-flight requests means no limit;
+// the burst defaults to the rate rounded up.
+type RateLimit struct {
+	RequestsPerSecond float64
+	Burst             int
+	MaxInFlight       int
+}
+
+// RateLimitOptions ...
+//
+// The default limit is shared by all the hosts
+// or applied to each of them separately if it's per host;
+// the host limits are always applied separately.
+// An adaptive client also waits for the reset of the limit
+// reported by the X-RateLimit-* or RateLimit-* response headers
+// or by the Retry-After header of the 429 response.
+// This waiting is applied only to the host that reported the limit,
+// even if the default limit is shared, and it's capped
+// with the maximal adaptive delay (DefaultMaxAdaptiveDelay if it's zero).
+type RateLimitOptions struct {
+	Limit            RateLimit
+	HostLimits       map[string]RateLimit
+	IsPerHost        bool
+	IsAdaptive       bool
+	MaxAdaptiveDelay time.Duration
+}
+
+// RateLimitClient ...
+type RateLimitClient struct {
+	HTTPClient httputils.HTTPClient
+	Options    RateLimitOptions
+	Clock      func() time.Time
+	Sleeper    Sleeper
+
+	lock         sync.Mutex
+	limiters     map[string]*rateLimiter
+	blockedHosts map[string]time.Time
+}
+
+// NewRateLimitClient ...
+func NewRateLimitClient(
+	httpClient httputils.HTTPClient,
+	options RateLimitOptions,
+) *RateLimitClient {
+	return &RateLimitClient{
+		HTTPClient:   httpClient,
+		Options:      options,
+		Clock:        time.Now,
+		Sleeper:      SleepWithContext,
+		limiters:     map[string]*rateLimiter{},
+		blockedHosts: map[string]time.Time{},
+	}
+}
+
+// Do ...
+//
+// The in-flight request ends when the response body is closed.
+func (client *RateLimitClient) Do(request *http.Request) (
+	*http.Response,
+	error,
+) {
+	ctx := request.Context()
+	host := request.URL.Host
+	limiter := client.getLimiter(host)
+	if err := limiter.acquireSlot(ctx); err != nil {
+		return nil, fmt.Errorf("unable to wait for an in-flight slot: %w", err)
+	}
+
+	if err := client.waitToken(ctx, host, limiter); err != nil {
+		limiter.releaseSlot()
+		return nil, fmt.Errorf("unable to wait for the rate limit: %w", err)
+	}
+
+	response, err := client.HTTPClient.Do(request)
+	if err != nil {
+		limiter.releaseSlot()
+		return nil, err
+	}
+
+	if client.Options.IsAdaptive {
+		client.lock.Lock()
+		client.adapt(host, limiter, response, client.Clock())
+		client.lock.Unlock()
+	}
+
+	if limiter.slots != nil {
+		response.Body = &slotReleasingReadCloser{
+			ReadCloser: response.Body,
+			release:    limiter.releaseSlot,
+		}
+	}
+
+	return response, nil
+}
+
+func (client *RateLimitClient) getLimiter(host string) *rateLimiter {
+	key, limit := "", client.Options.Limit
+	if hostLimit, ok := client.Options.HostLimits[host]; ok {
+		key, limit = host, hostLimit
+	} else if client.Options.IsPerHost {
+		key = host
+	}
+
+	client.lock.Lock()
+	defer client.lock.Unlock()
+
+	limiter, ok := client.limiters[key]
+	if !ok {
+		limiter = newRateLimiter(limit, client.Clock())
+		client.limiters[key] = limiter
+	}
+
+	return limiter
+}
+
+func (client *RateLimitClient) waitToken(
+	ctx context.Context,
+	host string,
+	limiter *rateLimiter,
+) error {
+	client.lock.Lock()
+	now := client.Clock()
+	delay := limiter.reserveToken(now)
+	if blockedUntil, ok := client.blockedHosts[host]; ok {
+		if blockedDelay := blockedUntil.Sub(now); blockedDelay > delay {
+			delay = blockedDelay
+		} else if blockedDelay <= 0 {
+			delete(client.blockedHosts, host)
+		}
+	}
+	client.lock.Unlock()
+
+	if delay <= 0 {
+		return nil
+	}
+
+	if err := client.Sleeper(ctx, delay); err != nil {
+		client.lock.Lock()
+		limiter.cancelToken()
+		client.lock.Unlock()
+
+		return err
+	}
+
+	return nil
+}
+
+func (client *RateLimitClient) adapt(
+	host string,
+	limiter *rateLimiter,
+	response *http.Response,
+	now time.Time,
+) {
+	if response.StatusCode == http.StatusTooManyRequests {
+		if retryAfter, ok := parseRetryAfter(response, now); ok {
+			client.block(host, now.Add(retryAfter), now)
+			return
+		}
+	}
+
+	remaining, resetAt, ok := parseRateLimitHeaders(response.Header, now)
+	if !ok {
+		return
+	}
+
+	if remaining <= 0 {
+		client.block(host, resetAt, now)
+		return
+	}
+
+	if limiter.limit.RequestsPerSecond > 0 {
+		limiter.tokens = math.Min(limiter.tokens, float64(remaining))
+	}
+}
+
+func (client *RateLimitClient) block(
+	host string,
+	until time.Time,
+	now time.Time,
+) {
+	maxDelay := client.Options.MaxAdaptiveDelay
+	if maxDelay <= 0 {
+		maxDelay = DefaultMaxAdaptiveDelay
+	}
+	if maxUntil := now.Add(maxDelay); until.After(maxUntil) {
+		until = maxUntil
+	}
+
+	if until.After(client.blockedHosts[host]) {
+		client.blockedHosts[host] = until
+	}
+}
+
+type rateLimiter struct {
+	limit     RateLimit
+	burst     float64
+	tokens    float64
+	updatedAt time.Time
+	slots     chan struct{}
+}
+
+func newRateLimiter(limit RateLimit, now time.Time) *rateLimiter {
+	burst := float64(limit.Burst)
+	if burst <= 0 {
+		burst = math.Max(1, math.Ceil(limit.RequestsPerSecond))
+	}
+
+	var slots chan struct{}
+	if limit.MaxInFlight > 0 {
+		slots = make(chan struct{}, limit.MaxInFlight)
+	}
+
+	return &rateLimiter{
+		limit:     limit,
+		burst:     burst,
+		tokens:    burst,
+		updatedAt: now,
+		slots:     slots,
+	}
+}
+
+func (limiter *rateLimiter) acquireSlot(ctx context.Context) error {
+	if limiter.slots == nil {
+		return nil
+	}
+
+	select {
+	case limiter.slots <- struct{}{}:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (limiter *rateLimiter) releaseSlot() {
+	if limiter.slots == nil {
+		return
+	}
+
+	<-limiter.slots
+}
+
+// it takes a token in advance and returns the delay to wait for it,
+// so concurrent requests queue up instead of competing for the same token
+func (limiter *rateLimiter) reserveToken(now time.Time) time.Duration {
+	var delay time.Duration
+	if rate := limiter.limit.RequestsPerSecond; rate > 0 {
+		if elapsedTime := now.Sub(limiter.updatedAt); elapsedTime > 0 {
+			refilledTokens := limiter.tokens + elapsedTime.Seconds()*rate
+			limiter.tokens = math.Min(limiter.burst, refilledTokens)
+			limiter.updatedAt = now
+		}
+
+		limiter.tokens--
+		if limiter.tokens < 0 {
+			delay = time.Duration(-limiter.tokens / rate * float64(time.Second))
+		}
+	}
+
+	return delay
+}
+
+func (limiter *rateLimiter) cancelToken() {
+	if limiter.limit.RequestsPerSecond > 0 {
+		limiter.tokens++
+	}
+}
+
+// it supports the de facto X-RateLimit-* headers and the RateLimit-* ones
+// of the IETF draft; the reset is either delay seconds or Unix time
+func parseRateLimitHeaders(header http.Header, now time.Time) (
+	remaining int,
+	resetAt time.Time,
+	ok bool,
+) {
+	for _, prefix := range []string{"RateLimit-", "X-RateLimit-"} {
+		rawRemaining := strings.TrimSpace(header.Get(prefix + "Remaining"))
+		if rawRemaining == "" {
+			continue
+		}
+
+		remaining, err := strconv.Atoi(rawRemaining)
+		if err != nil {
+			continue
+		}
+
+		resetAt := now
+		rawReset := strings.TrimSpace(header.Get(prefix + "Reset"))
+		reset, err := strconv.ParseInt(rawReset, 10, 64)
+		if err == nil && reset > 0 {
+			if reset > minUnixTimeReset {
+				resetAt = time.Unix(reset, 0)
+			} else {
+				resetAt = now.Add(time.Duration(reset) * time.Second)
+			}
+		}
+
+		return remaining, resetAt, true
+	}
+
+	return 0, time.Time{}, false
+}
+
+type slotReleasingReadCloser struct {
+	io.ReadCloser
+
+	release func()
+	once    sync.Once
+}
+
+func (reader *slotReleasingReadCloser) Close() error {
+	defer reader.once.Do(reader.release)
+	return reader.ReadCloser.Close()
+}
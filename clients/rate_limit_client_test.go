@@ -0,0 +1,416 @@
+package clients
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"testing/iotest"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewRateLimitClient(t *testing.T) {
+	httpClient := &MockHTTPClient{}
+	options := RateLimitOptions{Limit: RateLimit{RequestsPerSecond: 2}}
+	got := NewRateLimitClient(httpClient, options)
+
+	assert.Equal(t, httpClient, got.HTTPClient)
+	assert.Equal(t, options, got.Options)
+	assert.NotNil(t, got.Clock)
+	assert.NotNil(t, got.Sleeper)
+}
+
+func TestRateLimitClient_Do(t *testing.T) {
+	type step struct {
+		host       string
+		status     int
+		header     http.Header
+		wantSleeps []time.Duration
+	}
+
+	tests := []struct {
+		name    string
+		options RateLimitOptions
+		steps   []step
+	}{
+		{
+			name: "with the shared limit",
+			options: RateLimitOptions{
+				Limit: RateLimit{RequestsPerSecond: 2},
+			},
+			steps: []step{
+				{host: "one.example.com", wantSleeps: nil},
+				{host: "two.example.com", wantSleeps: nil},
+				{
+					host:       "one.example.com",
+					wantSleeps: []time.Duration{500 * time.Millisecond},
+				},
+				{
+					host:       "two.example.com",
+					wantSleeps: []time.Duration{500 * time.Millisecond},
+				},
+			},
+		},
+		{
+			name: "with the burst",
+			options: RateLimitOptions{
+				Limit: RateLimit{RequestsPerSecond: 1, Burst: 3},
+			},
+			steps: []step{
+				{host: "example.com", wantSleeps: nil},
+				{host: "example.com", wantSleeps: nil},
+				{host: "example.com", wantSleeps: nil},
+				{host: "example.com", wantSleeps: []time.Duration{time.Second}},
+			},
+		},
+		{
+			name: "with the limit per host",
+			options: RateLimitOptions{
+				Limit:     RateLimit{RequestsPerSecond: 1},
+				IsPerHost: true,
+			},
+			steps: []step{
+				{host: "one.example.com", wantSleeps: nil},
+				{host: "two.example.com", wantSleeps: nil},
+				{host: "one.example.com", wantSleeps: []time.Duration{time.Second}},
+			},
+		},
+		{
+			name: "with the host limits",
+			options: RateLimitOptions{
+				HostLimits: map[string]RateLimit{
+					"one.example.com": {RequestsPerSecond: 1},
+				},
+			},
+			steps: []step{
+				{host: "one.example.com", wantSleeps: nil},
+				{host: "two.example.com", wantSleeps: nil},
+				{host: "two.example.com", wantSleeps: nil},
+				{host: "one.example.com", wantSleeps: []time.Duration{time.Second}},
+			},
+		},
+		{
+			name: "with the adaptation to the X-RateLimit-* headers",
+			options: RateLimitOptions{
+				IsAdaptive: true,
+			},
+			steps: []step{
+				{
+					host: "example.com",
+					header: http.Header{
+						"X-Ratelimit-Remaining": {"0"},
+						"X-Ratelimit-Reset":     {"30"},
+					},
+					wantSleeps: nil,
+				},
+				{host: "example.com", wantSleeps: []time.Duration{30 * time.Second}},
+				{host: "example.com", wantSleeps: nil},
+			},
+		},
+		{
+			name: "with the adaptation to the RateLimit-* headers",
+			options: RateLimitOptions{
+				Limit:      RateLimit{RequestsPerSecond: 10},
+				IsAdaptive: true,
+			},
+			steps: []step{
+				{
+					host: "example.com",
+					header: http.Header{
+						"Ratelimit-Remaining": {"1"},
+						"Ratelimit-Reset":     {"1"},
+					},
+					wantSleeps: nil,
+				},
+				{host: "example.com", wantSleeps: nil},
+				{
+					host:       "example.com",
+					wantSleeps: []time.Duration{100 * time.Millisecond},
+				},
+			},
+		},
+		{
+			name: "with the adaptation to the Retry-After header",
+			options: RateLimitOptions{
+				IsAdaptive: true,
+			},
+			steps: []step{
+				{
+					host:       "example.com",
+					status:     http.StatusTooManyRequests,
+					header:     http.Header{"Retry-After": {"5"}},
+					wantSleeps: nil,
+				},
+				{host: "example.com", wantSleeps: []time.Duration{5 * time.Second}},
+			},
+		},
+		{
+			name: "with the adaptation of the shared limit per host",
+			options: RateLimitOptions{
+				Limit:      RateLimit{RequestsPerSecond: 10},
+				IsAdaptive: true,
+			},
+			steps: []step{
+				{
+					host:       "one.example.com",
+					status:     http.StatusTooManyRequests,
+					header:     http.Header{"Retry-After": {"5"}},
+					wantSleeps: nil,
+				},
+				{host: "two.example.com", wantSleeps: nil},
+				{
+					host:       "one.example.com",
+					wantSleeps: []time.Duration{5 * time.Second},
+				},
+			},
+		},
+		{
+			name: "with the default maximal adaptive delay",
+			options: RateLimitOptions{
+				IsAdaptive: true,
+			},
+			steps: []step{
+				{
+					host:       "example.com",
+					status:     http.StatusTooManyRequests,
+					header:     http.Header{"Retry-After": {"86400"}},
+					wantSleeps: nil,
+				},
+				{
+					host:       "example.com",
+					wantSleeps: []time.Duration{DefaultMaxAdaptiveDelay},
+				},
+			},
+		},
+		{
+			name: "with the custom maximal adaptive delay",
+			options: RateLimitOptions{
+				IsAdaptive:       true,
+				MaxAdaptiveDelay: 10 * time.Second,
+			},
+			steps: []step{
+				{
+					host: "example.com",
+					header: http.Header{
+						"X-Ratelimit-Remaining": {"0"},
+						"X-Ratelimit-Reset":     {"3600"},
+					},
+					wantSleeps: nil,
+				},
+				{host: "example.com", wantSleeps: []time.Duration{10 * time.Second}},
+			},
+		},
+		{
+			name: "without the adaptation",
+			options: RateLimitOptions{
+				IsAdaptive: false,
+			},
+			steps: []step{
+				{
+					host:       "example.com",
+					status:     http.StatusTooManyRequests,
+					header:     http.Header{"Retry-After": {"5"}},
+					wantSleeps: nil,
+				},
+				{host: "example.com", wantSleeps: nil},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			httpClient := &MockHTTPClient{}
+			for _, step := range tt.steps {
+				status := step.status
+				if status == 0 {
+					status = http.StatusOK
+				}
+
+				httpClient.InnerMock.
+					On("Do", mock.AnythingOfType("*http.Request")).
+					Return(&http.Response{
+						StatusCode: status,
+						Header:     step.header,
+						Body:       ioutil.NopCloser(strings.NewReader("test")),
+					}, nil).
+					Times(1)
+			}
+
+			var gotSleeps []time.Duration
+			now := time.Date(2021, time.January, 15, 4, 16, 50, 0, time.UTC)
+			client := NewRateLimitClient(httpClient, tt.options)
+			client.Clock = func() time.Time { return now }
+			client.Sleeper = func(ctx context.Context, duration time.Duration) error {
+				gotSleeps = append(gotSleeps, duration)
+				now = now.Add(duration)
+
+				return nil
+			}
+
+			for index, step := range tt.steps {
+				gotSleeps = nil
+
+				url := "http://" + step.host + "/"
+				request := httptest.NewRequest(http.MethodGet, url, nil)
+				response, err := client.Do(request)
+				require.NoError(t, err, "step #%d", index)
+				require.NoError(t, response.Body.Close(), "step #%d", index)
+
+				assert.Equal(t, step.wantSleeps, gotSleeps, "step #%d", index)
+			}
+
+			httpClient.InnerMock.AssertExpectations(t)
+		})
+	}
+}
+
+func TestRateLimitClient_Do_withSleepingError(t *testing.T) {
+	httpClient := &MockHTTPClient{}
+	httpClient.InnerMock.
+		On("Do", mock.AnythingOfType("*http.Request")).
+		Return(&http.Response{
+			StatusCode: http.StatusOK,
+			Body:       ioutil.NopCloser(strings.NewReader("test")),
+		}, nil).
+		Times(1)
+
+	var gotSleeps []time.Duration
+	now := time.Date(2021, time.January, 15, 4, 16, 50, 0, time.UTC)
+	client := NewRateLimitClient(
+		httpClient,
+		RateLimitOptions{Limit: RateLimit{RequestsPerSecond: 1}},
+	)
+	client.Clock = func() time.Time { return now }
+	client.Sleeper = func(ctx context.Context, duration time.Duration) error {
+		gotSleeps = append(gotSleeps, duration)
+		return context.Canceled
+	}
+
+	request := httptest.NewRequest(http.MethodGet, "http://example.com/", nil)
+	_, err := client.Do(request)
+	require.NoError(t, err)
+
+	for index := 0; index < 2; index++ {
+		_, err = client.Do(request)
+		assert.True(t, errors.Is(err, context.Canceled), "attempt #%d", index)
+	}
+
+	httpClient.InnerMock.AssertExpectations(t)
+	// the token of the cancelled request is returned
+	assert.Equal(t, []time.Duration{time.Second, time.Second}, gotSleeps)
+}
+
+func TestRateLimitClient_Do_withMaxInFlight(t *testing.T) {
+	httpClient := &MockHTTPClient{}
+	httpClient.InnerMock.
+		On("Do", mock.AnythingOfType("*http.Request")).
+		Return((*http.Response)(nil), iotest.ErrTimeout).
+		Times(1)
+	httpClient.InnerMock.
+		On("Do", mock.AnythingOfType("*http.Request")).
+		Return(&http.Response{
+			StatusCode: http.StatusOK,
+			Body:       ioutil.NopCloser(strings.NewReader("test")),
+		}, nil).
+		Times(2)
+
+	client := NewRateLimitClient(
+		httpClient,
+		RateLimitOptions{Limit: RateLimit{MaxInFlight: 1}},
+	)
+
+	request := httptest.NewRequest(http.MethodGet, "http://example.com/", nil)
+	_, err := client.Do(request)
+	require.Equal(t, iotest.ErrTimeout, err)
+
+	response, err := client.Do(request)
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	_, err = client.Do(request.WithContext(ctx))
+	assert.True(t, errors.Is(err, context.DeadlineExceeded))
+
+	require.NoError(t, response.Body.Close())
+	require.NoError(t, response.Body.Close())
+
+	response, err = client.Do(request)
+	require.NoError(t, err)
+	require.NoError(t, response.Body.Close())
+
+	httpClient.InnerMock.AssertExpectations(t)
+}
+
+func Test_parseRateLimitHeaders(t *testing.T) {
+	now := time.Date(2021, time.January, 15, 4, 16, 50, 0, time.UTC)
+
+	tests := []struct {
+		name          string
+		header        http.Header
+		wantRemaining int
+		wantResetAt   time.Time
+		wantOk        bool
+	}{
+		{
+			name: "success with the RateLimit-* headers",
+			header: http.Header{
+				"Ratelimit-Remaining": {"5"},
+				"Ratelimit-Reset":     {"10"},
+			},
+			wantRemaining: 5,
+			wantResetAt:   now.Add(10 * time.Second),
+			wantOk:        true,
+		},
+		{
+			name: "success with the X-RateLimit-* headers and Unix time",
+			header: http.Header{
+				"X-Ratelimit-Remaining": {"0"},
+				"X-Ratelimit-Reset":     {"1610684240"},
+			},
+			wantRemaining: 0,
+			wantResetAt:   time.Unix(1610684240, 0),
+			wantOk:        true,
+		},
+		{
+			name: "success without the reset",
+			header: http.Header{
+				"X-Ratelimit-Remaining": {"0"},
+			},
+			wantRemaining: 0,
+			wantResetAt:   now,
+			wantOk:        true,
+		},
+		{
+			name:          "error without headers",
+			header:        http.Header{},
+			wantRemaining: 0,
+			wantResetAt:   time.Time{},
+			wantOk:        false,
+		},
+		{
+			name: "error with the incorrect remaining",
+			header: http.Header{
+				"Ratelimit-Remaining": {"incorrect"},
+			},
+			wantRemaining: 0,
+			wantResetAt:   time.Time{},
+			wantOk:        false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotRemaining, gotResetAt, gotOk := parseRateLimitHeaders(tt.header, now)
+
+			assert.Equal(t, tt.wantRemaining, gotRemaining)
+			assert.True(t, tt.wantResetAt.Equal(gotResetAt))
+			assert.Equal(t, tt.wantOk, gotOk)
+		})
+	}
+}
@@ -0,0 +1,284 @@
+package clients
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"math"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	httputils "github.com/irenicaa/go-http-utils"
+)
+
+// ...
+const (
+	DefaultMaxAttempts     = 3
+	DefaultInitialInterval = 100 * time.Millisecond
+	DefaultMaxInterval     = 10 * time.Second
+	DefaultMultiplier      = 2.0
+)
+
+// IdempotencyKeyHeader ...
+const IdempotencyKeyHeader = "Idempotency-Key"
+
+// maximal amount of a response body that is read to reuse the connection
+const maxDrainedBodySize = 4 << 10
+
+// greater delay seconds of the Retry-After header would overflow a duration
+const maxRetryAfterSeconds = math.MaxInt64 / int64(time.Second)
+
+// DefaultRetryStatuses ...
+var DefaultRetryStatuses = []int{
+	http.StatusTooManyRequests,
+	http.StatusBadGateway,
+	http.StatusServiceUnavailable,
+	http.StatusGatewayTimeout,
+}
+
+// Sleeper ...
+type Sleeper func(ctx context.Context, duration time.Duration) error
+
+// SleepWithContext ...
+func SleepWithContext(ctx context.Context, duration time.Duration) error {
+	timer := time.NewTimer(duration)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// RetryOptions ...
+//
+// Zero values are replaced with the defaults;
+// a zero maximal elapsed time means it isn't limited.
+// A Retry-After delay greater than the maximal interval stops the retries.
+type RetryOptions struct {
+	MaxAttempts     int
+	MaxElapsedTime  time.Duration
+	InitialInterval time.Duration
+	MaxInterval     time.Duration
+	Multiplier      float64
+	RetryStatuses   []int
+}
+
+// RetryClient ...
+//
+// It retries idempotent requests, i.e. ones with an idempotent method
+// or the Idempotency-Key header, on transport failures and retry statuses.
+// A request with a body is retried only if it can be rewound with GetBody.
+type RetryClient struct {
+	HTTPClient httputils.HTTPClient
+	Logger     httputils.Logger
+	Options    RetryOptions
+	Clock      func() time.Time
+	Sleeper    Sleeper
+	Random     func() float64
+}
+
+// NewRetryClient ...
+func NewRetryClient(
+	httpClient httputils.HTTPClient,
+	logger httputils.Logger,
+	options RetryOptions,
+) RetryClient {
+	if options.MaxAttempts == 0 {
+		options.MaxAttempts = DefaultMaxAttempts
+	}
+	if options.InitialInterval == 0 {
+		options.InitialInterval = DefaultInitialInterval
+	}
+	if options.MaxInterval == 0 {
+		options.MaxInterval = DefaultMaxInterval
+	}
+	if options.Multiplier == 0 {
+		options.Multiplier = DefaultMultiplier
+	}
+	if options.RetryStatuses == nil {
+		options.RetryStatuses = DefaultRetryStatuses
+	}
+
+	return RetryClient{
+		HTTPClient: httpClient,
+		Logger:     logger,
+		Options:    options,
+		Clock:      time.Now,
+		Sleeper:    SleepWithContext,
+		Random:     rand.Float64,
+	}
+}
+
+// Do ...
+func (client RetryClient) Do(request *http.Request) (*http.Response, error) {
+	if !isRetryableRequest(request) {
+		return client.HTTPClient.Do(request)
+	}
+
+	ctx := request.Context()
+	startTime := client.Clock()
+	for attempt := 1; ; attempt++ {
+		attemptRequest, err := rewindRequest(request, attempt)
+		if err != nil {
+			return nil, err
+		}
+
+		response, err := client.HTTPClient.Do(attemptRequest)
+		if err != nil && ctx.Err() != nil {
+			return nil, err
+		}
+		if err == nil &&
+			!containsStatus(client.Options.RetryStatuses, response.StatusCode) {
+			return response, nil
+		}
+		if attempt >= client.Options.MaxAttempts {
+			return response, wrapAttemptsError(err, attempt)
+		}
+
+		delay := client.getBackoff(attempt)
+		if response != nil {
+			if retryAfter, ok := parseRetryAfter(response, client.Clock()); ok {
+				// the server won't accept the request earlier,
+				// so waiting for less is pointless
+				if retryAfter > client.Options.MaxInterval {
+					return response, wrapAttemptsError(err, attempt)
+				}
+
+				delay = retryAfter
+			}
+		}
+		elapsedTime := client.Clock().Sub(startTime) + delay
+		if client.Options.MaxElapsedTime > 0 &&
+			elapsedTime > client.Options.MaxElapsedTime {
+			return response, wrapAttemptsError(err, attempt)
+		}
+
+		reason := err
+		if response != nil {
+			reason = errors.New(response.Status)
+			drainResponse(response)
+		}
+
+		client.Logger.Print(fmt.Sprintf(
+			"%s %s: retrying the request in %s (attempt #%d of %d): %s",
+			request.Method,
+			request.URL,
+			delay,
+			attempt+1,
+			client.Options.MaxAttempts,
+			reason,
+		))
+
+		if err := client.Sleeper(ctx, delay); err != nil {
+			return nil, fmt.Errorf("unable to wait for the retry: %w", err)
+		}
+	}
+}
+
+func (client RetryClient) getBackoff(attempt int) time.Duration {
+	interval := float64(client.Options.InitialInterval) *
+		math.Pow(client.Options.Multiplier, float64(attempt-1))
+	if interval > float64(client.Options.MaxInterval) {
+		interval = float64(client.Options.MaxInterval)
+	}
+
+	// full jitter
+	return time.Duration(client.Random() * interval)
+}
+
+func isRetryableRequest(request *http.Request) bool {
+	if request.Body != nil && request.Body != http.NoBody &&
+		request.GetBody == nil {
+		return false
+	}
+
+	switch request.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodTrace,
+		http.MethodPut, http.MethodDelete:
+		return true
+	default:
+		return request.Header.Get(IdempotencyKeyHeader) != ""
+	}
+}
+
+func rewindRequest(request *http.Request, attempt int) (*http.Request, error) {
+	if attempt == 1 || request.GetBody == nil {
+		return request, nil
+	}
+
+	body, err := request.GetBody()
+	if err != nil {
+		return nil, fmt.Errorf("unable to rewind the request body: %w", err)
+	}
+
+	rewoundRequest := request.Clone(request.Context())
+	rewoundRequest.Body = body
+
+	return rewoundRequest, nil
+}
+
+// it supports both formats of the Retry-After header:
+// delay seconds and HTTP-date (RFC 7231, section 7.1.3)
+func parseRetryAfter(response *http.Response, now time.Time) (
+	time.Duration,
+	bool,
+) {
+	retryAfter := strings.TrimSpace(response.Header.Get("Retry-After"))
+	if retryAfter == "" {
+		return 0, false
+	}
+
+	if seconds, err := strconv.ParseInt(retryAfter, 10, 64); err == nil {
+		if seconds < 0 {
+			return 0, false
+		}
+		if seconds > maxRetryAfterSeconds {
+			seconds = maxRetryAfterSeconds
+		}
+
+		return time.Duration(seconds) * time.Second, true
+	}
+
+	date, err := http.ParseTime(retryAfter)
+	if err != nil {
+		return 0, false
+	}
+
+	delay := date.Sub(now)
+	if delay < 0 {
+		delay = 0
+	}
+
+	return delay, true
+}
+
+func drainResponse(response *http.Response) {
+	io.CopyN(ioutil.Discard, response.Body, maxDrainedBodySize)
+	response.Body.Close()
+}
+
+func wrapAttemptsError(err error, attempts int) error {
+	if err == nil || attempts == 1 {
+		return err
+	}
+
+	return fmt.Errorf("request was failed after %d attempts: %w", attempts, err)
+}
+
+func containsStatus(statuses []int, status int) bool {
+	for _, candidate := range statuses {
+		if candidate == status {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,719 @@
+package clients
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"testing/iotest"
+	"time"
+
+	httputils "github.com/irenicaa/go-http-utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSleepWithContext(t *testing.T) {
+	type args struct {
+		ctx      context.Context
+		duration time.Duration
+	}
+
+	tests := []struct {
+		name    string
+		args    args
+		wantErr error
+	}{
+		{
+			name: "success",
+			args: args{
+				ctx:      context.Background(),
+				duration: time.Millisecond,
+			},
+			wantErr: nil,
+		},
+		{
+			name: "error",
+			args: args{
+				ctx: func() context.Context {
+					ctx, cancel := context.WithCancel(context.Background())
+					cancel()
+
+					return ctx
+				}(),
+				duration: time.Hour,
+			},
+			wantErr: context.Canceled,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := SleepWithContext(tt.args.ctx, tt.args.duration)
+
+			assert.Equal(t, tt.wantErr, err)
+		})
+	}
+}
+
+func TestNewRetryClient(t *testing.T) {
+	httpClient := &MockHTTPClient{}
+	logger := &MockLogger{}
+	got := NewRetryClient(httpClient, logger, RetryOptions{MaxAttempts: 5})
+
+	assert.Equal(t, httpClient, got.HTTPClient)
+	assert.Equal(t, logger, got.Logger)
+	assert.Equal(t, RetryOptions{
+		MaxAttempts:     5,
+		InitialInterval: DefaultInitialInterval,
+		MaxInterval:     DefaultMaxInterval,
+		Multiplier:      DefaultMultiplier,
+		RetryStatuses:   DefaultRetryStatuses,
+	}, got.Options)
+	assert.NotNil(t, got.Clock)
+	assert.NotNil(t, got.Sleeper)
+	assert.NotNil(t, got.Random)
+}
+
+func TestRetryClient_Do(t *testing.T) {
+	clockTime := time.Date(2021, time.January, 15, 4, 16, 50, 0, time.UTC)
+	makeResponse := func(status int, header http.Header, body string) *http.Response {
+		return &http.Response{
+			Status:     strconv.Itoa(status) + " " + http.StatusText(status),
+			StatusCode: status,
+			Header:     header,
+			Body:       ioutil.NopCloser(strings.NewReader(body)),
+		}
+	}
+	matchBody := func(wantBody string) interface{} {
+		return mock.MatchedBy(func(request *http.Request) bool {
+			body, err := ioutil.ReadAll(request.Body)
+			require.NoError(t, err)
+
+			request.Body = ioutil.NopCloser(bytes.NewReader(body))
+			return string(body) == wantBody
+		})
+	}
+
+	type fields struct {
+		HTTPClient httputils.HTTPClient
+		Logger     *MockLogger
+		Options    RetryOptions
+	}
+	type args struct {
+		request *http.Request
+	}
+
+	tests := []struct {
+		name       string
+		fields     fields
+		args       args
+		sleeperErr error
+		wantSleeps []time.Duration
+		wantStatus int
+		wantErr    assert.ErrorAssertionFunc
+	}{
+		{
+			name: "success on the first attempt",
+			fields: fields{
+				HTTPClient: func() httputils.HTTPClient {
+					httpClient := &MockHTTPClient{}
+					httpClient.InnerMock.
+						On("Do", mock.AnythingOfType("*http.Request")).
+						Return(makeResponse(http.StatusOK, nil, "test"), nil).
+						Times(1)
+
+					return httpClient
+				}(),
+				Logger:  &MockLogger{},
+				Options: RetryOptions{},
+			},
+			args: args{
+				request: httptest.NewRequest(http.MethodGet, "http://example.com/", nil),
+			},
+			wantSleeps: nil,
+			wantStatus: http.StatusOK,
+			wantErr:    assert.NoError,
+		},
+		{
+			name: "success after the retry status",
+			fields: fields{
+				HTTPClient: func() httputils.HTTPClient {
+					httpClient := &MockHTTPClient{}
+					httpClient.InnerMock.
+						On("Do", mock.AnythingOfType("*http.Request")).
+						Return(makeResponse(http.StatusBadGateway, nil, "error"), nil).
+						Times(2)
+					httpClient.InnerMock.
+						On("Do", mock.AnythingOfType("*http.Request")).
+						Return(makeResponse(http.StatusOK, nil, "test"), nil).
+						Times(1)
+
+					return httpClient
+				}(),
+				Logger: func() *MockLogger {
+					logger := &MockLogger{}
+					logger.InnerMock.
+						On("Print", []interface{}{
+							"GET http://example.com/: retrying the request in 50ms " +
+								"(attempt #2 of 3): 502 Bad Gateway",
+						}).
+						Return().
+						Times(1)
+					logger.InnerMock.
+						On("Print", []interface{}{
+							"GET http://example.com/: retrying the request in 100ms " +
+								"(attempt #3 of 3): 502 Bad Gateway",
+						}).
+						Return().
+						Times(1)
+
+					return logger
+				}(),
+				Options: RetryOptions{},
+			},
+			args: args{
+				request: httptest.NewRequest(http.MethodGet, "http://example.com/", nil),
+			},
+			wantSleeps: []time.Duration{50 * time.Millisecond, 100 * time.Millisecond},
+			wantStatus: http.StatusOK,
+			wantErr:    assert.NoError,
+		},
+		{
+			name: "success after the transport failure",
+			fields: fields{
+				HTTPClient: func() httputils.HTTPClient {
+					httpClient := &MockHTTPClient{}
+					httpClient.InnerMock.
+						On("Do", mock.AnythingOfType("*http.Request")).
+						Return((*http.Response)(nil), iotest.ErrTimeout).
+						Times(1)
+					httpClient.InnerMock.
+						On("Do", mock.AnythingOfType("*http.Request")).
+						Return(makeResponse(http.StatusOK, nil, "test"), nil).
+						Times(1)
+
+					return httpClient
+				}(),
+				Logger: func() *MockLogger {
+					logger := &MockLogger{}
+					logger.InnerMock.
+						On("Print", []interface{}{
+							"DELETE http://example.com/: retrying the request in 50ms " +
+								"(attempt #2 of 3): timeout",
+						}).
+						Return().
+						Times(1)
+
+					return logger
+				}(),
+				Options: RetryOptions{},
+			},
+			args: args{
+				request: httptest.NewRequest(http.MethodDelete, "http://example.com/", nil),
+			},
+			wantSleeps: []time.Duration{50 * time.Millisecond},
+			wantStatus: http.StatusOK,
+			wantErr:    assert.NoError,
+		},
+		{
+			name: "success with the Retry-After header in seconds",
+			fields: fields{
+				HTTPClient: func() httputils.HTTPClient {
+					response := makeResponse(
+						http.StatusTooManyRequests,
+						http.Header{"Retry-After": {"2"}},
+						"error",
+					)
+
+					httpClient := &MockHTTPClient{}
+					httpClient.InnerMock.
+						On("Do", mock.AnythingOfType("*http.Request")).
+						Return(response, nil).
+						Times(1)
+					httpClient.InnerMock.
+						On("Do", mock.AnythingOfType("*http.Request")).
+						Return(makeResponse(http.StatusOK, nil, "test"), nil).
+						Times(1)
+
+					return httpClient
+				}(),
+				Logger: func() *MockLogger {
+					logger := &MockLogger{}
+					logger.InnerMock.
+						On("Print", []interface{}{
+							"GET http://example.com/: retrying the request in 2s " +
+								"(attempt #2 of 3): 429 Too Many Requests",
+						}).
+						Return().
+						Times(1)
+
+					return logger
+				}(),
+				Options: RetryOptions{},
+			},
+			args: args{
+				request: httptest.NewRequest(http.MethodGet, "http://example.com/", nil),
+			},
+			wantSleeps: []time.Duration{2 * time.Second},
+			wantStatus: http.StatusOK,
+			wantErr:    assert.NoError,
+		},
+		{
+			name: "success with the Retry-After header in the HTTP-date format",
+			fields: fields{
+				HTTPClient: func() httputils.HTTPClient {
+					retryAfter := clockTime.Add(3 * time.Second).Format(http.TimeFormat)
+					response := makeResponse(
+						http.StatusServiceUnavailable,
+						http.Header{"Retry-After": {retryAfter}},
+						"error",
+					)
+
+					httpClient := &MockHTTPClient{}
+					httpClient.InnerMock.
+						On("Do", mock.AnythingOfType("*http.Request")).
+						Return(response, nil).
+						Times(1)
+					httpClient.InnerMock.
+						On("Do", mock.AnythingOfType("*http.Request")).
+						Return(makeResponse(http.StatusOK, nil, "test"), nil).
+						Times(1)
+
+					return httpClient
+				}(),
+				Logger: func() *MockLogger {
+					logger := &MockLogger{}
+					logger.InnerMock.
+						On("Print", []interface{}{
+							"GET http://example.com/: retrying the request in 3s " +
+								"(attempt #2 of 3): 503 Service Unavailable",
+						}).
+						Return().
+						Times(1)
+
+					return logger
+				}(),
+				Options: RetryOptions{},
+			},
+			args: args{
+				request: httptest.NewRequest(http.MethodGet, "http://example.com/", nil),
+			},
+			wantSleeps: []time.Duration{3 * time.Second},
+			wantStatus: http.StatusOK,
+			wantErr:    assert.NoError,
+		},
+		{
+			name: "success with the rewinding of the request body",
+			fields: fields{
+				HTTPClient: func() httputils.HTTPClient {
+					httpClient := &MockHTTPClient{}
+					httpClient.InnerMock.
+						On("Do", matchBody("test")).
+						Return(makeResponse(http.StatusBadGateway, nil, "error"), nil).
+						Times(1)
+					httpClient.InnerMock.
+						On("Do", matchBody("test")).
+						Return(makeResponse(http.StatusCreated, nil, "test"), nil).
+						Times(1)
+
+					return httpClient
+				}(),
+				Logger: func() *MockLogger {
+					logger := &MockLogger{}
+					logger.InnerMock.
+						On("Print", []interface{}{
+							"POST http://example.com/: retrying the request in 50ms " +
+								"(attempt #2 of 3): 502 Bad Gateway",
+						}).
+						Return().
+						Times(1)
+
+					return logger
+				}(),
+				Options: RetryOptions{},
+			},
+			args: args{
+				request: func() *http.Request {
+					request, err := http.NewRequest(
+						http.MethodPost,
+						"http://example.com/",
+						strings.NewReader("test"),
+					)
+					require.NoError(t, err)
+
+					request.Header.Set(IdempotencyKeyHeader, "23")
+					return request
+				}(),
+			},
+			wantSleeps: []time.Duration{50 * time.Millisecond},
+			wantStatus: http.StatusCreated,
+			wantErr:    assert.NoError,
+		},
+		{
+			name: "without retries of a non-idempotent request",
+			fields: fields{
+				HTTPClient: func() httputils.HTTPClient {
+					httpClient := &MockHTTPClient{}
+					httpClient.InnerMock.
+						On("Do", mock.AnythingOfType("*http.Request")).
+						Return(makeResponse(http.StatusBadGateway, nil, "error"), nil).
+						Times(1)
+
+					return httpClient
+				}(),
+				Logger:  &MockLogger{},
+				Options: RetryOptions{},
+			},
+			args: args{
+				request: httptest.NewRequest(
+					http.MethodPost,
+					"http://example.com/",
+					strings.NewReader("test"),
+				),
+			},
+			wantSleeps: nil,
+			wantStatus: http.StatusBadGateway,
+			wantErr:    assert.NoError,
+		},
+		{
+			name: "without retries of a non-rewindable request",
+			fields: fields{
+				HTTPClient: func() httputils.HTTPClient {
+					httpClient := &MockHTTPClient{}
+					httpClient.InnerMock.
+						On("Do", mock.AnythingOfType("*http.Request")).
+						Return(makeResponse(http.StatusBadGateway, nil, "error"), nil).
+						Times(1)
+
+					return httpClient
+				}(),
+				Logger:  &MockLogger{},
+				Options: RetryOptions{},
+			},
+			args: args{
+				request: httptest.NewRequest(
+					http.MethodPut,
+					"http://example.com/",
+					iotest.OneByteReader(strings.NewReader("test")),
+				),
+			},
+			wantSleeps: nil,
+			wantStatus: http.StatusBadGateway,
+			wantErr:    assert.NoError,
+		},
+		{
+			name: "error with the exhausted attempts on the retry status",
+			fields: fields{
+				HTTPClient: func() httputils.HTTPClient {
+					httpClient := &MockHTTPClient{}
+					httpClient.InnerMock.
+						On("Do", mock.AnythingOfType("*http.Request")).
+						Return(makeResponse(http.StatusBadGateway, nil, "error"), nil).
+						Times(2)
+
+					return httpClient
+				}(),
+				Logger: func() *MockLogger {
+					logger := &MockLogger{}
+					logger.InnerMock.
+						On("Print", []interface{}{
+							"GET http://example.com/: retrying the request in 50ms " +
+								"(attempt #2 of 2): 502 Bad Gateway",
+						}).
+						Return().
+						Times(1)
+
+					return logger
+				}(),
+				Options: RetryOptions{MaxAttempts: 2},
+			},
+			args: args{
+				request: httptest.NewRequest(http.MethodGet, "http://example.com/", nil),
+			},
+			wantSleeps: []time.Duration{50 * time.Millisecond},
+			wantStatus: http.StatusBadGateway,
+			wantErr:    assert.NoError,
+		},
+		{
+			name: "error with the exhausted attempts on the transport failure",
+			fields: fields{
+				HTTPClient: func() httputils.HTTPClient {
+					httpClient := &MockHTTPClient{}
+					httpClient.InnerMock.
+						On("Do", mock.AnythingOfType("*http.Request")).
+						Return((*http.Response)(nil), iotest.ErrTimeout).
+						Times(2)
+
+					return httpClient
+				}(),
+				Logger: func() *MockLogger {
+					logger := &MockLogger{}
+					logger.InnerMock.
+						On("Print", []interface{}{
+							"GET http://example.com/: retrying the request in 50ms " +
+								"(attempt #2 of 2): timeout",
+						}).
+						Return().
+						Times(1)
+
+					return logger
+				}(),
+				Options: RetryOptions{MaxAttempts: 2},
+			},
+			args: args{
+				request: httptest.NewRequest(http.MethodGet, "http://example.com/", nil),
+			},
+			wantSleeps: []time.Duration{50 * time.Millisecond},
+			wantStatus: 0,
+			wantErr: func(
+				t assert.TestingT,
+				err error,
+				msgAndArgs ...interface{},
+			) bool {
+				return assert.EqualError(
+					t,
+					err,
+					"request was failed after 2 attempts: timeout",
+					msgAndArgs...,
+				)
+			},
+		},
+		{
+			name: "error with the exceeded elapsed time",
+			fields: fields{
+				HTTPClient: func() httputils.HTTPClient {
+					response := makeResponse(
+						http.StatusServiceUnavailable,
+						http.Header{"Retry-After": {"60"}},
+						"error",
+					)
+
+					httpClient := &MockHTTPClient{}
+					httpClient.InnerMock.
+						On("Do", mock.AnythingOfType("*http.Request")).
+						Return(response, nil).
+						Times(1)
+
+					return httpClient
+				}(),
+				Logger: &MockLogger{},
+				Options: RetryOptions{
+					MaxElapsedTime: 10 * time.Second,
+					MaxInterval:    time.Minute,
+				},
+			},
+			args: args{
+				request: httptest.NewRequest(http.MethodGet, "http://example.com/", nil),
+			},
+			wantSleeps: nil,
+			wantStatus: http.StatusServiceUnavailable,
+			wantErr:    assert.NoError,
+		},
+		{
+			name: "error with the Retry-After header above the maximal interval",
+			fields: fields{
+				HTTPClient: func() httputils.HTTPClient {
+					response := makeResponse(
+						http.StatusTooManyRequests,
+						http.Header{"Retry-After": {"86400"}},
+						"error",
+					)
+
+					httpClient := &MockHTTPClient{}
+					httpClient.InnerMock.
+						On("Do", mock.AnythingOfType("*http.Request")).
+						Return(response, nil).
+						Times(1)
+
+					return httpClient
+				}(),
+				Logger:  &MockLogger{},
+				Options: RetryOptions{},
+			},
+			args: args{
+				request: httptest.NewRequest(http.MethodGet, "http://example.com/", nil),
+			},
+			wantSleeps: nil,
+			wantStatus: http.StatusTooManyRequests,
+			wantErr:    assert.NoError,
+		},
+		{
+			name: "error with the cancelled context",
+			fields: fields{
+				HTTPClient: func() httputils.HTTPClient {
+					httpClient := &MockHTTPClient{}
+					httpClient.InnerMock.
+						On("Do", mock.AnythingOfType("*http.Request")).
+						Return((*http.Response)(nil), context.Canceled).
+						Times(1)
+
+					return httpClient
+				}(),
+				Logger:  &MockLogger{},
+				Options: RetryOptions{},
+			},
+			args: args{
+				request: func() *http.Request {
+					ctx, cancel := context.WithCancel(context.Background())
+					cancel()
+
+					return httptest.
+						NewRequest(http.MethodGet, "http://example.com/", nil).
+						WithContext(ctx)
+				}(),
+			},
+			wantSleeps: nil,
+			wantStatus: 0,
+			wantErr: func(
+				t assert.TestingT,
+				err error,
+				msgAndArgs ...interface{},
+			) bool {
+				return assert.Equal(t, context.Canceled, err, msgAndArgs...)
+			},
+		},
+		{
+			name: "error with the sleeping",
+			fields: fields{
+				HTTPClient: func() httputils.HTTPClient {
+					httpClient := &MockHTTPClient{}
+					httpClient.InnerMock.
+						On("Do", mock.AnythingOfType("*http.Request")).
+						Return(makeResponse(http.StatusBadGateway, nil, "error"), nil).
+						Times(1)
+
+					return httpClient
+				}(),
+				Logger: func() *MockLogger {
+					logger := &MockLogger{}
+					logger.InnerMock.
+						On("Print", []interface{}{
+							"GET http://example.com/: retrying the request in 50ms " +
+								"(attempt #2 of 3): 502 Bad Gateway",
+						}).
+						Return().
+						Times(1)
+
+					return logger
+				}(),
+				Options: RetryOptions{},
+			},
+			args: args{
+				request: httptest.NewRequest(http.MethodGet, "http://example.com/", nil),
+			},
+			sleeperErr: context.DeadlineExceeded,
+			wantSleeps: []time.Duration{50 * time.Millisecond},
+			wantStatus: 0,
+			wantErr: func(
+				t assert.TestingT,
+				err error,
+				msgAndArgs ...interface{},
+			) bool {
+				return assert.True(
+					t,
+					errors.Is(err, context.DeadlineExceeded),
+					msgAndArgs...,
+				)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var gotSleeps []time.Duration
+			client :=
+				NewRetryClient(tt.fields.HTTPClient, tt.fields.Logger, tt.fields.Options)
+			client.Clock = func() time.Time { return clockTime }
+			client.Sleeper = func(ctx context.Context, duration time.Duration) error {
+				gotSleeps = append(gotSleeps, duration)
+				return tt.sleeperErr
+			}
+			client.Random = func() float64 { return 0.5 }
+
+			got, err := client.Do(tt.args.request)
+
+			tt.fields.HTTPClient.(*MockHTTPClient).InnerMock.AssertExpectations(t)
+			tt.fields.Logger.InnerMock.AssertExpectations(t)
+			assert.Equal(t, tt.wantSleeps, gotSleeps)
+			if tt.wantStatus != 0 {
+				require.NotNil(t, got)
+				assert.Equal(t, tt.wantStatus, got.StatusCode)
+			} else {
+				assert.Nil(t, got)
+			}
+			tt.wantErr(t, err)
+		})
+	}
+}
+
+func Test_parseRetryAfter(t *testing.T) {
+	now := time.Date(2021, time.January, 15, 4, 16, 50, 0, time.UTC)
+
+	tests := []struct {
+		name       string
+		retryAfter string
+		wantDelay  time.Duration
+		wantOk     bool
+	}{
+		{
+			name:       "success with seconds",
+			retryAfter: "120",
+			wantDelay:  2 * time.Minute,
+			wantOk:     true,
+		},
+		{
+			name:       "success with the HTTP-date",
+			retryAfter: "Fri, 15 Jan 2021 04:17:00 GMT",
+			wantDelay:  10 * time.Second,
+			wantOk:     true,
+		},
+		{
+			name:       "success with the past HTTP-date",
+			retryAfter: "Fri, 15 Jan 2021 04:16:00 GMT",
+			wantDelay:  0,
+			wantOk:     true,
+		},
+		{
+			name:       "success with too many seconds",
+			retryAfter: "99999999999",
+			wantDelay:  time.Duration(maxRetryAfterSeconds) * time.Second,
+			wantOk:     true,
+		},
+		{
+			name:       "error with the missed header",
+			retryAfter: "",
+			wantDelay:  0,
+			wantOk:     false,
+		},
+		{
+			name:       "error with negative seconds",
+			retryAfter: "-1",
+			wantDelay:  0,
+			wantOk:     false,
+		},
+		{
+			name:       "error with the incorrect value",
+			retryAfter: "incorrect",
+			wantDelay:  0,
+			wantOk:     false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			response := &http.Response{Header: http.Header{}}
+			if tt.retryAfter != "" {
+				response.Header.Set("Retry-After", tt.retryAfter)
+			}
+
+			gotDelay, gotOk := parseRetryAfter(response, now)
+
+			assert.Equal(t, tt.wantDelay, gotDelay)
+			assert.Equal(t, tt.wantOk, gotOk)
+		})
+	}
+}
@@ -0,0 +1,281 @@
+package httputils
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// ...
+const (
+	JSONMediaType = "application/json"
+	XMLMediaType  = "application/xml"
+	CSVMediaType  = "text/csv"
+	FormMediaType = "application/x-www-form-urlencoded"
+)
+
+// ErrNotAcceptable ...
+var ErrNotAcceptable = errors.New("not acceptable")
+
+// DefaultCodecRegistry ...
+var DefaultCodecRegistry = NewCodecRegistry()
+
+func init() {
+	DefaultCodecRegistry.Register(JSONMediaType, JSONCodec{})
+	DefaultCodecRegistry.Register(XMLMediaType, XMLCodec{})
+	DefaultCodecRegistry.Register(CSVMediaType, CSVCodec{})
+	DefaultCodecRegistry.Register(FormMediaType, FormCodec{})
+}
+
+// Codec ...
+type Codec interface {
+	Encode(writer io.Writer, data interface{}) error
+	Decode(reader io.Reader, data interface{}) error
+}
+
+// CodecRegistry ...
+type CodecRegistry struct {
+	mediaTypes []string
+	codecs     map[string]Codec
+}
+
+// NewCodecRegistry ...
+func NewCodecRegistry() *CodecRegistry {
+	return &CodecRegistry{codecs: map[string]Codec{}}
+}
+
+// Register ...
+//
+// The first registered media type is used
+// when a request doesn't specify the Accept header.
+// Registering the same media type again replaces its codec
+// but keeps its priority.
+func (registry *CodecRegistry) Register(mediaType string, codec Codec) {
+	mediaType = strings.ToLower(mediaType)
+	if _, ok := registry.codecs[mediaType]; !ok {
+		registry.mediaTypes = append(registry.mediaTypes, mediaType)
+	}
+
+	registry.codecs[mediaType] = codec
+}
+
+// Negotiate ...
+func (registry *CodecRegistry) Negotiate(acceptHeader string) (
+	mediaType string,
+	codec Codec,
+	err error,
+) {
+	if len(registry.mediaTypes) == 0 {
+		return "", nil, fmt.Errorf("%w: no codecs are registered", ErrNotAcceptable)
+	}
+	if strings.TrimSpace(acceptHeader) == "" {
+		mediaType = registry.mediaTypes[0]
+		return mediaType, registry.codecs[mediaType], nil
+	}
+
+	mediaRanges := parseAcceptHeader(acceptHeader)
+	bestQuality := 0.0
+	for _, candidate := range registry.mediaTypes {
+		quality, ok := matchMediaRanges(candidate, mediaRanges)
+		if !ok || quality <= bestQuality {
+			continue
+		}
+
+		mediaType, bestQuality = candidate, quality
+	}
+	if mediaType == "" {
+		return "", nil, fmt.Errorf("%w: %q", ErrNotAcceptable, acceptHeader)
+	}
+
+	return mediaType, registry.codecs[mediaType], nil
+}
+
+// Lookup ...
+func (registry *CodecRegistry) Lookup(contentType string) (
+	mediaType string,
+	codec Codec,
+	err error,
+) {
+	mediaType, _, err = mime.ParseMediaType(contentType)
+	if err != nil {
+		return "", nil, fmt.Errorf("%w: %q", ErrUnsupportedContentType, contentType)
+	}
+
+	if codec, ok := registry.codecs[mediaType]; ok {
+		return mediaType, codec, nil
+	}
+
+	// fall back to the codec of the structured syntax suffix (RFC 6839)
+	if suffixIndex := strings.LastIndex(mediaType, "+"); suffixIndex != -1 {
+		suffixMediaType := "application/" + mediaType[suffixIndex+1:]
+		if codec, ok := registry.codecs[suffixMediaType]; ok {
+			return suffixMediaType, codec, nil
+		}
+	}
+
+	return "", nil, fmt.Errorf("%w: %q", ErrUnsupportedContentType, contentType)
+}
+
+// WriteResponse ...
+func (registry *CodecRegistry) WriteResponse(
+	writer http.ResponseWriter,
+	request *http.Request,
+	logger Logger,
+	status int,
+	data interface{},
+) {
+	writer.Header().Add("Vary", "Accept")
+
+	mediaType, codec, err := registry.Negotiate(request.Header.Get("Accept"))
+	if err != nil {
+		HandleError(
+			writer,
+			logger,
+			http.StatusNotAcceptable,
+			"unable to negotiate the content type: %s",
+			err,
+		)
+
+		return
+	}
+
+	writer.Header().Set("Content-Type", mediaType)
+	writer.WriteHeader(status)
+
+	if err := codec.Encode(writer, data); err != nil {
+		logger.Print(fmt.Sprintf("unable to encode the data: %s", err))
+	}
+}
+
+// DecodeRequest ...
+func (registry *CodecRegistry) DecodeRequest(
+	request *http.Request,
+	data interface{},
+) error {
+	_, codec, err := registry.Lookup(request.Header.Get("Content-Type"))
+	if err != nil {
+		return err
+	}
+
+	if err := codec.Decode(request.Body, data); err != nil {
+		if ErrorStatus(err) == http.StatusInternalServerError {
+			err = NewHTTPError(
+				http.StatusBadRequest,
+				"invalid request data",
+				err,
+			)
+		}
+
+		return err
+	}
+
+	return nil
+}
+
+// ReadRequest ...
+func (registry *CodecRegistry) ReadRequest(
+	writer http.ResponseWriter,
+	request *http.Request,
+	logger Logger,
+	data interface{},
+) bool {
+	if err := registry.DecodeRequest(request, data); err != nil {
+		HandleError(
+			writer,
+			logger,
+			ErrorStatus(err),
+			"unable to decode the request: %s",
+			err,
+		)
+
+		return false
+	}
+
+	return true
+}
+
+type mediaRange struct {
+	mainType    string
+	subType     string
+	quality     float64
+	specificity int
+}
+
+func parseAcceptHeader(acceptHeader string) []mediaRange {
+	var mediaRanges []mediaRange
+	for _, part := range strings.Split(acceptHeader, ",") {
+		mediaType, parameters, err := mime.ParseMediaType(strings.TrimSpace(part))
+		if err != nil {
+			continue
+		}
+
+		slashIndex := strings.Index(mediaType, "/")
+		if slashIndex == -1 {
+			continue
+		}
+
+		mainType, subType := mediaType[:slashIndex], mediaType[slashIndex+1:]
+		if mainType == "*" && subType != "*" {
+			continue
+		}
+
+		quality := 1.0
+		if rawQuality, ok := parameters["q"]; ok {
+			quality, err = strconv.ParseFloat(rawQuality, 64)
+			if err != nil || quality < 0 || quality > 1 {
+				continue
+			}
+		}
+
+		specificity := 2
+		switch {
+		case mainType == "*":
+			specificity = 0
+		case subType == "*":
+			specificity = 1
+		}
+
+		mediaRanges = append(mediaRanges, mediaRange{
+			mainType:    mainType,
+			subType:     subType,
+			quality:     quality,
+			specificity: specificity,
+		})
+	}
+
+	// the most specific range takes precedence (RFC 7231, section 5.3.2)
+	sort.SliceStable(mediaRanges, func(i int, j int) bool {
+		return mediaRanges[i].specificity > mediaRanges[j].specificity
+	})
+
+	return mediaRanges
+}
+
+func matchMediaRanges(mediaType string, mediaRanges []mediaRange) (
+	quality float64,
+	ok bool,
+) {
+	slashIndex := strings.Index(mediaType, "/")
+	if slashIndex == -1 {
+		return 0, false
+	}
+
+	mainType, subType := mediaType[:slashIndex], mediaType[slashIndex+1:]
+	for _, mediaRange := range mediaRanges {
+		if mediaRange.mainType != "*" && mediaRange.mainType != mainType {
+			continue
+		}
+		if mediaRange.subType != "*" && mediaRange.subType != subType {
+			continue
+		}
+
+		return mediaRange.quality, mediaRange.quality > 0
+	}
+
+	return 0, false
+}
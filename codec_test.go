@@ -0,0 +1,364 @@
+package httputils
+
+import (
+	"bytes"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCodecRegistry_Negotiate(t *testing.T) {
+	type args struct {
+		acceptHeader string
+	}
+
+	tests := []struct {
+		name          string
+		args          args
+		wantMediaType string
+		wantCodec     Codec
+		wantErr       assert.ErrorAssertionFunc
+	}{
+		{
+			name:          "success without the header",
+			args:          args{acceptHeader: ""},
+			wantMediaType: JSONMediaType,
+			wantCodec:     JSONCodec{},
+			wantErr:       assert.NoError,
+		},
+		{
+			name:          "success with an exact media type",
+			args:          args{acceptHeader: "text/csv"},
+			wantMediaType: CSVMediaType,
+			wantCodec:     CSVCodec{},
+			wantErr:       assert.NoError,
+		},
+		{
+			name: "success with quality values",
+			args: args{
+				acceptHeader: "application/json;q=0.5, application/xml;q=0.9, */*;q=0.1",
+			},
+			wantMediaType: XMLMediaType,
+			wantCodec:     XMLCodec{},
+			wantErr:       assert.NoError,
+		},
+		{
+			name:          "success with a wildcard",
+			args:          args{acceptHeader: "text/html, text/*;q=0.8"},
+			wantMediaType: CSVMediaType,
+			wantCodec:     CSVCodec{},
+			wantErr:       assert.NoError,
+		},
+		{
+			name: "success with a more specific range",
+			args: args{
+				acceptHeader: "*/*, application/json;q=0",
+			},
+			wantMediaType: XMLMediaType,
+			wantCodec:     XMLCodec{},
+			wantErr:       assert.NoError,
+		},
+		{
+			name:          "error with an unknown media type",
+			args:          args{acceptHeader: "text/html, image/*"},
+			wantMediaType: "",
+			wantCodec:     nil,
+			wantErr: func(t assert.TestingT, err error, msgAndArgs ...interface{}) bool {
+				return assert.ErrorIs(t, err, ErrNotAcceptable, msgAndArgs...)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotMediaType, gotCodec, err :=
+				DefaultCodecRegistry.Negotiate(tt.args.acceptHeader)
+
+			assert.Equal(t, tt.wantMediaType, gotMediaType)
+			assert.Equal(t, tt.wantCodec, gotCodec)
+			tt.wantErr(t, err)
+		})
+	}
+}
+
+func TestCodecRegistry_Lookup(t *testing.T) {
+	type args struct {
+		contentType string
+	}
+
+	tests := []struct {
+		name          string
+		args          args
+		wantMediaType string
+		wantCodec     Codec
+		wantErr       assert.ErrorAssertionFunc
+	}{
+		{
+			name:          "success with parameters",
+			args:          args{contentType: "Application/XML; charset=utf-8"},
+			wantMediaType: XMLMediaType,
+			wantCodec:     XMLCodec{},
+			wantErr:       assert.NoError,
+		},
+		{
+			name:          "success with a structured syntax suffix",
+			args:          args{contentType: "application/merge-patch+json"},
+			wantMediaType: JSONMediaType,
+			wantCodec:     JSONCodec{},
+			wantErr:       assert.NoError,
+		},
+		{
+			name:          "error with an unknown media type",
+			args:          args{contentType: "text/plain"},
+			wantMediaType: "",
+			wantCodec:     nil,
+			wantErr: func(t assert.TestingT, err error, msgAndArgs ...interface{}) bool {
+				return assert.ErrorIs(t, err, ErrUnsupportedContentType, msgAndArgs...)
+			},
+		},
+		{
+			name:          "error without a media type",
+			args:          args{contentType: ""},
+			wantMediaType: "",
+			wantCodec:     nil,
+			wantErr: func(t assert.TestingT, err error, msgAndArgs ...interface{}) bool {
+				return assert.ErrorIs(t, err, ErrUnsupportedContentType, msgAndArgs...)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotMediaType, gotCodec, err :=
+				DefaultCodecRegistry.Lookup(tt.args.contentType)
+
+			assert.Equal(t, tt.wantMediaType, gotMediaType)
+			assert.Equal(t, tt.wantCodec, gotCodec)
+			tt.wantErr(t, err)
+		})
+	}
+}
+
+func TestCodecRegistry_WriteResponse(t *testing.T) {
+	type testData struct {
+		FieldOne int
+		FieldTwo string
+	}
+	type args struct {
+		acceptHeader string
+		logger       Logger
+		status       int
+		data         interface{}
+	}
+
+	tests := []struct {
+		name         string
+		args         args
+		wantResponse *http.Response
+	}{
+		{
+			name: "success with CSV",
+			args: args{
+				acceptHeader: "text/csv, application/json;q=0.5",
+				logger:       &MockLogger{},
+				status:       http.StatusOK,
+				data:         []testData{{FieldOne: 23, FieldTwo: "one"}},
+			},
+			wantResponse: &http.Response{
+				Status: strconv.Itoa(http.StatusOK) + " " +
+					http.StatusText(http.StatusOK),
+				StatusCode: http.StatusOK,
+				Proto:      "HTTP/1.1",
+				ProtoMajor: 1,
+				ProtoMinor: 1,
+				Header: http.Header{
+					"Content-Type": {CSVMediaType},
+					"Vary":         {"Accept"},
+				},
+				Body: ioutil.NopCloser(bytes.NewReader(
+					[]byte("FieldOne,FieldTwo\n23,one\n"),
+				)),
+				ContentLength: -1,
+			},
+		},
+		{
+			name: "error with negotiation",
+			args: args{
+				acceptHeader: "text/html",
+				logger: func() Logger {
+					logger := &MockLogger{}
+					logger.InnerMock.
+						On("Print", []interface{}{
+							"unable to negotiate the content type: " +
+								"not acceptable: \"text/html\"",
+						}).
+						Return().
+						Times(1)
+
+					return logger
+				}(),
+				status: http.StatusOK,
+				data:   []testData{{FieldOne: 23, FieldTwo: "one"}},
+			},
+			wantResponse: &http.Response{
+				Status: strconv.Itoa(http.StatusNotAcceptable) + " " +
+					http.StatusText(http.StatusNotAcceptable),
+				StatusCode: http.StatusNotAcceptable,
+				Proto:      "HTTP/1.1",
+				ProtoMajor: 1,
+				ProtoMinor: 1,
+				Header:     http.Header{"Vary": {"Accept"}},
+				Body: ioutil.NopCloser(bytes.NewReader([]byte(
+					"unable to negotiate the content type: not acceptable: \"text/html\"",
+				))),
+				ContentLength: -1,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			request := httptest.NewRequest(http.MethodGet, "http://example.com/test", nil)
+			request.Header.Set("Accept", tt.args.acceptHeader)
+
+			responseRecorder := httptest.NewRecorder()
+			DefaultCodecRegistry.WriteResponse(
+				responseRecorder,
+				request,
+				tt.args.logger,
+				tt.args.status,
+				tt.args.data,
+			)
+
+			tt.args.logger.(*MockLogger).InnerMock.AssertExpectations(t)
+			assert.Equal(t, tt.wantResponse, responseRecorder.Result())
+		})
+	}
+}
+
+func TestCodecRegistry_ReadRequest(t *testing.T) {
+	type testData struct {
+		FieldOne int    `xml:"field_one" bind:"name=field_one"`
+		FieldTwo string `xml:"field_two" bind:"name=field_two"`
+	}
+	type args struct {
+		contentType string
+		body        string
+		logger      Logger
+	}
+
+	tests := []struct {
+		name       string
+		args       args
+		wantData   testData
+		wantOK     bool
+		wantStatus int
+	}{
+		{
+			name: "success with JSON",
+			args: args{
+				contentType: "application/json",
+				body:        `{"FieldOne": 23, "FieldTwo": "one"}`,
+				logger:      &MockLogger{},
+			},
+			wantData:   testData{FieldOne: 23, FieldTwo: "one"},
+			wantOK:     true,
+			wantStatus: http.StatusOK,
+		},
+		{
+			name: "success with XML",
+			args: args{
+				contentType: "application/xml",
+				body: "<testData><field_one>23</field_one>" +
+					"<field_two>one</field_two></testData>",
+				logger: &MockLogger{},
+			},
+			wantData:   testData{FieldOne: 23, FieldTwo: "one"},
+			wantOK:     true,
+			wantStatus: http.StatusOK,
+		},
+		{
+			name: "success with a form",
+			args: args{
+				contentType: FormMediaType,
+				body:        "field_one=23&field_two=one",
+				logger:      &MockLogger{},
+			},
+			wantData:   testData{FieldOne: 23, FieldTwo: "one"},
+			wantOK:     true,
+			wantStatus: http.StatusOK,
+		},
+		{
+			name: "error with the content type",
+			args: args{
+				contentType: "text/plain",
+				body:        "test",
+				logger: func() Logger {
+					logger := &MockLogger{}
+					logger.InnerMock.
+						On("Print", []interface{}{
+							"unable to decode the request: " +
+								"unsupported content type: \"text/plain\"",
+						}).
+						Return().
+						Times(1)
+
+					return logger
+				}(),
+			},
+			wantData:   testData{},
+			wantOK:     false,
+			wantStatus: http.StatusUnsupportedMediaType,
+		},
+		{
+			name: "error with decoding",
+			args: args{
+				contentType: "application/xml",
+				body:        "<testData>",
+				logger: func() Logger {
+					logger := &MockLogger{}
+					logger.InnerMock.
+						On("Print", []interface{}{
+							"unable to decode the request: " +
+								"invalid request data: " +
+								"unable to decode the XML data: XML syntax error on line 1: " +
+								"unexpected EOF",
+						}).
+						Return().
+						Times(1)
+
+					return logger
+				}(),
+			},
+			wantData:   testData{},
+			wantOK:     false,
+			wantStatus: http.StatusBadRequest,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			request := httptest.NewRequest(
+				http.MethodPost,
+				"http://example.com/test",
+				strings.NewReader(tt.args.body),
+			)
+			request.Header.Set("Content-Type", tt.args.contentType)
+
+			var data testData
+			responseRecorder := httptest.NewRecorder()
+			gotOK := DefaultCodecRegistry.ReadRequest(
+				responseRecorder,
+				request,
+				tt.args.logger,
+				&data,
+			)
+
+			tt.args.logger.(*MockLogger).InnerMock.AssertExpectations(t)
+			assert.Equal(t, tt.wantData, data)
+			assert.Equal(t, tt.wantOK, gotOK)
+			assert.Equal(t, tt.wantStatus, responseRecorder.Code)
+		})
+	}
+}
@@ -0,0 +1,262 @@
+package httputils
+
+import (
+	"encoding"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/url"
+	"reflect"
+)
+
+// ...
+const (
+	DefaultMaxXMLBodySize  = 1 << 20
+	DefaultMaxFormBodySize = 10 << 20
+)
+
+// JSONCodec ...
+//
+// It decodes the data with the same limits and checks as DecodeJSONRequest.
+type JSONCodec struct {
+	Options JSONDecodingOptions
+}
+
+// Encode ...
+func (codec JSONCodec) Encode(writer io.Writer, data interface{}) error {
+	return encodeJSON(writer, data, "")
+}
+
+// Decode ...
+func (codec JSONCodec) Decode(reader io.Reader, data interface{}) error {
+	return decodeJSON(reader, data, codec.Options)
+}
+
+// XMLCodec ...
+//
+// A zero maximal body size means the default one, a negative one means no limit.
+type XMLCodec struct {
+	MaxBodySize int64
+}
+
+// Encode ...
+func (codec XMLCodec) Encode(writer io.Writer, data interface{}) error {
+	if _, err := io.WriteString(writer, xml.Header); err != nil {
+		return fmt.Errorf("unable to write the XML header: %w", err)
+	}
+
+	if err := xml.NewEncoder(writer).Encode(data); err != nil {
+		return fmt.Errorf("unable to encode the XML data: %w", err)
+	}
+
+	return nil
+}
+
+// Decode ...
+func (codec XMLCodec) Decode(reader io.Reader, data interface{}) error {
+	reader = limitBodySize(reader, codec.MaxBodySize, DefaultMaxXMLBodySize)
+	if err := xml.NewDecoder(reader).Decode(data); err != nil {
+		return fmt.Errorf("unable to decode the XML data: %w", err)
+	}
+
+	return nil
+}
+
+// FormCodec ...
+//
+// It encodes and decodes url.Values and structs;
+// the latter use the fields with the form source of the bind tag.
+// A zero maximal body size means the default one, a negative one means no limit.
+type FormCodec struct {
+	MaxBodySize int64
+}
+
+// Encode ...
+func (codec FormCodec) Encode(writer io.Writer, data interface{}) error {
+	values, err := makeFormValues(data)
+	if err != nil {
+		return err
+	}
+
+	if _, err := io.WriteString(writer, values.Encode()); err != nil {
+		return fmt.Errorf("unable to write the form data: %w", err)
+	}
+
+	return nil
+}
+
+// Decode ...
+func (codec FormCodec) Decode(reader io.Reader, data interface{}) error {
+	reader = limitBodySize(reader, codec.MaxBodySize, DefaultMaxFormBodySize)
+	formBytes, err := ioutil.ReadAll(reader)
+	if err != nil {
+		return fmt.Errorf("unable to read the form data: %w", err)
+	}
+
+	values, err := url.ParseQuery(string(formBytes))
+	if err != nil {
+		return fmt.Errorf("unable to parse the form data: %w", err)
+	}
+
+	if valuesPointer, ok := data.(*url.Values); ok {
+		*valuesPointer = values
+		return nil
+	}
+
+	return bindValues(data, func(source string, name string) ([]string, error) {
+		if source != defaultBindSource {
+			return nil, errSkippedSource
+		}
+
+		return values[name], nil
+	}, nil)
+}
+
+func limitBodySize(
+	reader io.Reader,
+	maxBodySize int64,
+	defaultMaxBodySize int64,
+) io.Reader {
+	if maxBodySize == 0 {
+		maxBodySize = defaultMaxBodySize
+	}
+	if maxBodySize < 0 {
+		return reader
+	}
+
+	return &bodySizeLimiter{
+		limitedReader: io.LimitedReader{R: reader, N: maxBodySize + 1},
+		maxBodySize:   maxBodySize,
+	}
+}
+
+// it fails as soon as the body exceeds the limit,
+// unlike io.LimitedReader that silently truncates the body
+type bodySizeLimiter struct {
+	limitedReader io.LimitedReader
+	maxBodySize   int64
+}
+
+func (limiter *bodySizeLimiter) Read(buffer []byte) (int, error) {
+	n, err := limiter.limitedReader.Read(buffer)
+	if limiter.limitedReader.N <= 0 {
+		return 0,
+			fmt.Errorf("%w: limit is %d bytes", ErrBodyTooLarge, limiter.maxBodySize)
+	}
+
+	return n, err
+}
+
+func makeFormValues(data interface{}) (url.Values, error) {
+	switch data := data.(type) {
+	case url.Values:
+		return data, nil
+	case map[string][]string:
+		return url.Values(data), nil
+	case map[string]string:
+		values := url.Values{}
+		for key, value := range data {
+			values.Set(key, value)
+		}
+
+		return values, nil
+	}
+
+	dataValue := reflect.Indirect(reflect.ValueOf(data))
+	if dataValue.Kind() != reflect.Struct {
+		return nil, errors.New("data should be a struct or form values")
+	}
+
+	values := url.Values{}
+	dataType := dataValue.Type()
+	for index := 0; index < dataType.NumField(); index++ {
+		field := dataType.Field(index)
+		tag, ok := field.Tag.Lookup(bindTagName)
+		if !ok || tag == "-" {
+			continue
+		}
+		if field.PkgPath != "" {
+			return nil,
+				fmt.Errorf("the %s field with the bind tag is unexported", field.Name)
+		}
+
+		options, err := parseBindOptions(field.Name, tag)
+		if err != nil {
+			return nil, fmt.Errorf(
+				"unable to parse the tag of the %s field: %w",
+				field.Name,
+				err,
+			)
+		}
+		if options.source != defaultBindSource {
+			continue
+		}
+
+		fieldValue := reflect.Indirect(dataValue.Field(index))
+		if !fieldValue.IsValid() {
+			continue
+		}
+
+		elementValues := []reflect.Value{fieldValue}
+		if fieldValue.Kind() == reflect.Slice &&
+			fieldValue.Type().Elem().Kind() != reflect.Uint8 {
+			elementValues = nil
+			for elementIndex := 0; elementIndex < fieldValue.Len(); elementIndex++ {
+				elementValues = append(elementValues, fieldValue.Index(elementIndex))
+			}
+		}
+
+		for _, elementValue := range elementValues {
+			formattedValue, err := formatTextValue(elementValue)
+			if err != nil {
+				return nil, fmt.Errorf(
+					"unable to format the %s field: %w",
+					field.Name,
+					err,
+				)
+			}
+
+			values.Add(options.name, formattedValue)
+		}
+	}
+
+	return values, nil
+}
+
+func formatTextValue(value reflect.Value) (string, error) {
+	if value.Kind() == reflect.Ptr {
+		if value.IsNil() {
+			return "", nil
+		}
+
+		value = value.Elem()
+	}
+
+	switch typedValue := value.Interface().(type) {
+	case encoding.TextMarshaler:
+		text, err := typedValue.MarshalText()
+		if err != nil {
+			return "", fmt.Errorf("unable to marshal the value: %w", err)
+		}
+
+		return string(text), nil
+	case fmt.Stringer:
+		return typedValue.String(), nil
+	case []byte:
+		return string(typedValue), nil
+	}
+
+	switch value.Kind() {
+	case reflect.String:
+		return value.String(), nil
+	case reflect.Bool,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return fmt.Sprint(value.Interface()), nil
+	default:
+		return "", fmt.Errorf("unsupported type %s", value.Type())
+	}
+}
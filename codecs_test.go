@@ -0,0 +1,247 @@
+package httputils
+
+import (
+	"bytes"
+	"errors"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/irenicaa/go-http-utils/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestJSONCodec(t *testing.T) {
+	type testData struct {
+		FieldOne int
+	}
+
+	var buffer bytes.Buffer
+	err := JSONCodec{}.Encode(&buffer, []testData{{FieldOne: 23}, {FieldOne: 42}})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "[{\"FieldOne\":23},{\"FieldOne\":42}]\n", buffer.String())
+
+	var data []testData
+	err = JSONCodec{}.Decode(strings.NewReader(buffer.String()), &data)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []testData{{FieldOne: 23}, {FieldOne: 42}}, data)
+
+	err = JSONCodec{}.Decode(strings.NewReader("incorrect"), &data)
+
+	assert.ErrorIs(t, err, ErrInvalidJSONData)
+	assert.Equal(t, JSONSyntaxError{
+		Offset: 1,
+		Line:   1,
+		Column: 2,
+		Err:    errors.Unwrap(err),
+	}, err)
+
+	err = JSONCodec{}.Decode(strings.NewReader("[] []"), &data)
+
+	assert.EqualError(t, err, "invalid JSON data: trailing data after the value")
+
+	codec := JSONCodec{Options: JSONDecodingOptions{MaxBodySize: 5}}
+	err = codec.Decode(strings.NewReader(buffer.String()), &data)
+
+	assert.ErrorIs(t, err, ErrBodyTooLarge)
+}
+
+func TestXMLCodec(t *testing.T) {
+	type testData struct {
+		FieldOne int `xml:"field_one"`
+	}
+
+	var buffer bytes.Buffer
+	err := XMLCodec{}.Encode(&buffer, testData{FieldOne: 23})
+
+	assert.NoError(t, err)
+	assert.Equal(
+		t,
+		"<?xml version=\"1.0\" encoding=\"UTF-8\"?>\n"+
+			"<testData><field_one>23</field_one></testData>",
+		buffer.String(),
+	)
+
+	var data testData
+	err = XMLCodec{}.Decode(strings.NewReader(buffer.String()), &data)
+
+	assert.NoError(t, err)
+	assert.Equal(t, testData{FieldOne: 23}, data)
+
+	codec := XMLCodec{MaxBodySize: int64(buffer.Len())}
+	err = codec.Decode(strings.NewReader(buffer.String()), &data)
+
+	assert.NoError(t, err)
+
+	codec = XMLCodec{MaxBodySize: 5}
+	err = codec.Decode(strings.NewReader(buffer.String()), &data)
+
+	assert.ErrorIs(t, err, ErrBodyTooLarge)
+}
+
+func TestFormCodec_Encode(t *testing.T) {
+	type testData struct {
+		FieldOne   int           `bind:"name=one"`
+		FieldTwo   []string      `bind:"name=two"`
+		FieldThree *models.Date  `bind:"name=three"`
+		FieldFour  time.Duration `bind:"name=four"`
+		FieldFive  *int          `bind:"name=five"`
+		FieldSix   string        `bind:"source=path,name=six"`
+		FieldSeven string
+	}
+	type args struct {
+		data interface{}
+	}
+
+	tests := []struct {
+		name    string
+		args    args
+		want    string
+		wantErr assert.ErrorAssertionFunc
+	}{
+		{
+			name: "success with a struct",
+			args: args{
+				data: testData{
+					FieldOne: 23,
+					FieldTwo: []string{"one", "two"},
+					FieldThree: func() *models.Date {
+						date := models.Date(time.Date(2006, time.January, 2, 0, 0, 0, 0, time.UTC))
+						return &date
+					}(),
+					FieldFour:  5 * time.Second,
+					FieldSix:   "path",
+					FieldSeven: "untagged",
+				},
+			},
+			want:    "four=5s&one=23&three=2006-01-02&two=one&two=two",
+			wantErr: assert.NoError,
+		},
+		{
+			name: "success with values",
+			args: args{
+				data: url.Values{"one": {"23"}, "two": {"one", "two"}},
+			},
+			want:    "one=23&two=one&two=two",
+			wantErr: assert.NoError,
+		},
+		{
+			name: "success with a map",
+			args: args{
+				data: map[string]string{"one": "23"},
+			},
+			want:    "one=23",
+			wantErr: assert.NoError,
+		},
+		{
+			name: "error with an unexported field",
+			args: args{
+				data: struct {
+					one int `bind:"name=one"`
+				}{one: 23},
+			},
+			want:    "",
+			wantErr: assert.Error,
+		},
+		{
+			name: "error with an unsupported type",
+			args: args{
+				data: []int{23, 42},
+			},
+			want:    "",
+			wantErr: assert.Error,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buffer bytes.Buffer
+			err := FormCodec{}.Encode(&buffer, tt.args.data)
+
+			assert.Equal(t, tt.want, buffer.String())
+			tt.wantErr(t, err)
+		})
+	}
+}
+
+func TestFormCodec_Decode(t *testing.T) {
+	type testData struct {
+		FieldOne   int      `bind:"name=one,max=100"`
+		FieldTwo   []string `bind:"name=two"`
+		FieldThree string   `bind:"source=path,name=three,required"`
+	}
+	type args struct {
+		maxBodySize int64
+		form        string
+		data        interface{}
+	}
+
+	tests := []struct {
+		name     string
+		args     args
+		wantData interface{}
+		wantErr  assert.ErrorAssertionFunc
+	}{
+		{
+			name: "success with a struct",
+			args: args{
+				form: "one=23&two=one&two=two",
+				data: &testData{},
+			},
+			wantData: &testData{FieldOne: 23, FieldTwo: []string{"one", "two"}},
+			wantErr:  assert.NoError,
+		},
+		{
+			name: "success with values",
+			args: args{
+				form: "one=23&two=one&two=two",
+				data: &url.Values{},
+			},
+			wantData: &url.Values{"one": {"23"}, "two": {"one", "two"}},
+			wantErr:  assert.NoError,
+		},
+		{
+			name: "error with the body size",
+			args: args{
+				maxBodySize: 5,
+				form:        "one=23&two=one&two=two",
+				data:        &testData{},
+			},
+			wantData: &testData{},
+			wantErr: func(t assert.TestingT, err error, msgAndArgs ...interface{}) bool {
+				return assert.ErrorIs(t, err, ErrBodyTooLarge, msgAndArgs...)
+			},
+		},
+		{
+			name: "error with parsing",
+			args: args{
+				form: "one=%zz",
+				data: &testData{},
+			},
+			wantData: &testData{},
+			wantErr:  assert.Error,
+		},
+		{
+			name: "error with validation",
+			args: args{
+				form: "one=123",
+				data: &testData{},
+			},
+			wantData: &testData{},
+			wantErr: func(t assert.TestingT, err error, msgAndArgs ...interface{}) bool {
+				return assert.ErrorIs(t, err, ErrValueTooGreater, msgAndArgs...)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			codec := FormCodec{MaxBodySize: tt.args.maxBodySize}
+			err := codec.Decode(strings.NewReader(tt.args.form), tt.args.data)
+
+			assert.Equal(t, tt.wantData, tt.args.data)
+			tt.wantErr(t, err)
+		})
+	}
+}
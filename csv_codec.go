@@ -0,0 +1,218 @@
+package httputils
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+)
+
+const csvTagName = "csv"
+
+// DefaultMaxCSVBodySize ...
+const DefaultMaxCSVBodySize = 10 << 20
+
+// CSVCodec ...
+//
+// It encodes and decodes slices of structs; a header of columns is made
+// from the csv tags of the struct fields or from their names.
+// A zero maximal body size means the default one, a negative one means no limit.
+type CSVCodec struct {
+	Delimiter   rune
+	MaxBodySize int64
+}
+
+// Encode ...
+func (codec CSVCodec) Encode(writer io.Writer, data interface{}) error {
+	dataValue := reflect.ValueOf(data)
+	if dataValue.Kind() != reflect.Slice && dataValue.Kind() != reflect.Array {
+		return errors.New("data should be a slice of structs")
+	}
+
+	columns, err := getCSVColumns(dataValue.Type().Elem())
+	if err != nil {
+		return err
+	}
+
+	return writeCSVRecords(
+		context.Background(),
+		writer,
+		codec.Delimiter,
+		0,
+		columns,
+		SliceRecordIterator(data),
+	)
+}
+
+// Decode ...
+func (codec CSVCodec) Decode(reader io.Reader, data interface{}) error {
+	dataValue := reflect.ValueOf(data)
+	if dataValue.Kind() != reflect.Ptr || dataValue.Elem().Kind() != reflect.Slice {
+		return errors.New("data should be a pointer to a slice of structs")
+	}
+
+	sliceValue := dataValue.Elem()
+	columns, err := getCSVColumns(sliceValue.Type().Elem())
+	if err != nil {
+		return err
+	}
+
+	reader = limitBodySize(reader, codec.MaxBodySize, DefaultMaxCSVBodySize)
+	csvReader := csv.NewReader(reader)
+	if codec.Delimiter != 0 {
+		csvReader.Comma = codec.Delimiter
+	}
+
+	header, err := csvReader.Read()
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+
+		return fmt.Errorf("unable to read the header: %w", err)
+	}
+
+	headerColumns := make([]*csvColumn, len(header))
+	for headerIndex, name := range header {
+		for columnIndex := range columns {
+			if columns[columnIndex].name == strings.TrimSpace(name) {
+				headerColumns[headerIndex] = &columns[columnIndex]
+				break
+			}
+		}
+	}
+
+	var validationErr ValidationError
+	for rowIndex := 0; ; rowIndex++ {
+		row, err := csvReader.Read()
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				break
+			}
+
+			return fmt.Errorf("unable to read the row #%d: %w", rowIndex, err)
+		}
+
+		elementValue := reflect.New(sliceValue.Type().Elem()).Elem()
+		for headerIndex, value := range row {
+			column := headerColumns[headerIndex]
+			if column == nil || value == "" {
+				continue
+			}
+
+			fieldValue := getCSVFieldValue(elementValue, column.index, true)
+			err := setFieldValue(fieldValue, []string{value}, bindOptions{})
+			if err != nil {
+				var fieldErr FieldError
+				if !errors.As(err, &fieldErr) {
+					return fmt.Errorf(
+						"unable to set the %s column of the row #%d: %w",
+						column.name,
+						rowIndex,
+						err,
+					)
+				}
+
+				field := fmt.Sprintf("%d.%s", rowIndex, column.name)
+				validationErr.AddError(field, fieldErr.Err)
+			}
+		}
+
+		sliceValue.Set(reflect.Append(sliceValue, elementValue))
+	}
+
+	return validationErr.ErrorOrNil()
+}
+
+type csvColumn struct {
+	name  string
+	index []int
+}
+
+func getCSVColumns(rowType reflect.Type) ([]csvColumn, error) {
+	if rowType.Kind() == reflect.Ptr {
+		rowType = rowType.Elem()
+	}
+	if rowType.Kind() != reflect.Struct {
+		return nil, errors.New("data should be a slice of structs")
+	}
+
+	var columns []csvColumn
+	for index := 0; index < rowType.NumField(); index++ {
+		field := rowType.Field(index)
+		if field.PkgPath != "" {
+			continue
+		}
+
+		name := field.Name
+		if tag, ok := field.Tag.Lookup(csvTagName); ok {
+			if tag == "-" {
+				continue
+			}
+
+			if tagName := strings.Split(tag, ",")[0]; tagName != "" {
+				name = tagName
+			}
+		}
+
+		columns = append(columns, csvColumn{name: name, index: field.Index})
+	}
+
+	return columns, nil
+}
+
+func getCSVHeader(columns []csvColumn) []string {
+	header := make([]string, 0, len(columns))
+	for _, column := range columns {
+		header = append(header, column.name)
+	}
+
+	return header
+}
+
+func makeCSVRow(columns []csvColumn, rowValue reflect.Value) ([]string, error) {
+	row := make([]string, 0, len(columns))
+	for _, column := range columns {
+		fieldValue := getCSVFieldValue(rowValue, column.index, false)
+		if !fieldValue.IsValid() {
+			row = append(row, "")
+			continue
+		}
+
+		formattedValue, err := formatTextValue(fieldValue)
+		if err != nil {
+			return nil, fmt.Errorf(
+				"unable to format the %s column: %w",
+				column.name,
+				err,
+			)
+		}
+
+		row = append(row, formattedValue)
+	}
+
+	return row, nil
+}
+
+func getCSVFieldValue(
+	rowValue reflect.Value,
+	index []int,
+	isAllocating bool,
+) reflect.Value {
+	if rowValue.Kind() == reflect.Ptr {
+		if rowValue.IsNil() {
+			if !isAllocating {
+				return reflect.Value{}
+			}
+
+			rowValue.Set(reflect.New(rowValue.Type().Elem()))
+		}
+
+		rowValue = rowValue.Elem()
+	}
+
+	return rowValue.FieldByIndex(index)
+}
@@ -0,0 +1,223 @@
+package httputils
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/irenicaa/go-http-utils/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCSVCodec_Encode(t *testing.T) {
+	type testData struct {
+		FieldOne   int         `csv:"one"`
+		FieldTwo   string      `csv:"two"`
+		FieldThree models.Date `csv:"three"`
+		FieldFour  *float64
+		FieldFive  string `csv:"-"`
+	}
+	type args struct {
+		codec CSVCodec
+		data  interface{}
+	}
+
+	tests := []struct {
+		name    string
+		args    args
+		want    string
+		wantErr assert.ErrorAssertionFunc
+	}{
+		{
+			name: "success",
+			args: args{
+				codec: CSVCodec{},
+				data: []testData{
+					{
+						FieldOne:   23,
+						FieldTwo:   "one, two",
+						FieldThree: models.Date(time.Date(2006, time.January, 2, 0, 0, 0, 0, time.UTC)),
+						FieldFour: func() *float64 {
+							value := 2.5
+							return &value
+						}(),
+						FieldFive: "hidden",
+					},
+					{FieldOne: 42},
+				},
+			},
+			want: "one,two,three,FieldFour\n" +
+				"23,\"one, two\",2006-01-02,2.5\n" +
+				"42,,0001-01-01,\n",
+			wantErr: assert.NoError,
+		},
+		{
+			name: "success with pointers and a delimiter",
+			args: args{
+				codec: CSVCodec{Delimiter: ';'},
+				data:  []*testData{{FieldOne: 23, FieldTwo: "one"}, nil},
+			},
+			want: "one;two;three;FieldFour\n" +
+				"23;one;0001-01-01;\n" +
+				";;;\n",
+			wantErr: assert.NoError,
+		},
+		{
+			name: "error with a non-slice",
+			args: args{
+				codec: CSVCodec{},
+				data:  testData{},
+			},
+			want:    "",
+			wantErr: assert.Error,
+		},
+		{
+			name: "error with a slice of non-structs",
+			args: args{
+				codec: CSVCodec{},
+				data:  []int{23, 42},
+			},
+			want:    "",
+			wantErr: assert.Error,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buffer bytes.Buffer
+			err := tt.args.codec.Encode(&buffer, tt.args.data)
+
+			assert.Equal(t, tt.want, buffer.String())
+			tt.wantErr(t, err)
+		})
+	}
+}
+
+func TestCSVCodec_Decode(t *testing.T) {
+	type testData struct {
+		FieldOne   int         `csv:"one"`
+		FieldTwo   string      `csv:"two"`
+		FieldThree models.Date `csv:"three"`
+		FieldFour  *float64
+	}
+	type args struct {
+		codec CSVCodec
+		csv   string
+		data  interface{}
+	}
+
+	tests := []struct {
+		name     string
+		args     args
+		wantData interface{}
+		wantErr  assert.ErrorAssertionFunc
+	}{
+		{
+			name: "success",
+			args: args{
+				codec: CSVCodec{},
+				csv: "two,one,unknown,three,FieldFour\n" +
+					"\"one, two\",23,test,2006-01-02,2.5\n" +
+					",42,,,\n",
+				data: &[]testData{},
+			},
+			wantData: &[]testData{
+				{
+					FieldOne:   23,
+					FieldTwo:   "one, two",
+					FieldThree: models.Date(time.Date(2006, time.January, 2, 0, 0, 0, 0, time.UTC)),
+					FieldFour: func() *float64 {
+						value := 2.5
+						return &value
+					}(),
+				},
+				{FieldOne: 42},
+			},
+			wantErr: assert.NoError,
+		},
+		{
+			name: "success with pointers and a delimiter",
+			args: args{
+				codec: CSVCodec{Delimiter: ';'},
+				csv:   "one;two\n23;one\n",
+				data:  &[]*testData{},
+			},
+			wantData: &[]*testData{{FieldOne: 23, FieldTwo: "one"}},
+			wantErr:  assert.NoError,
+		},
+		{
+			name: "success with an empty stream",
+			args: args{
+				codec: CSVCodec{},
+				csv:   "",
+				data:  &[]testData{},
+			},
+			wantData: &[]testData{},
+			wantErr:  assert.NoError,
+		},
+		{
+			name: "success with the body size at the limit",
+			args: args{
+				codec: CSVCodec{MaxBodySize: 15},
+				csv:   "one,two\n23,one\n",
+				data:  &[]testData{},
+			},
+			wantData: &[]testData{{FieldOne: 23, FieldTwo: "one"}},
+			wantErr:  assert.NoError,
+		},
+		{
+			name: "error with the body size",
+			args: args{
+				codec: CSVCodec{MaxBodySize: 5},
+				csv:   "one,two\n23,one\n",
+				data:  &[]testData{},
+			},
+			wantData: &[]testData{},
+			wantErr: func(t assert.TestingT, err error, msgAndArgs ...interface{}) bool {
+				return assert.ErrorIs(t, err, ErrBodyTooLarge, msgAndArgs...)
+			},
+		},
+		{
+			name: "error with validation",
+			args: args{
+				codec: CSVCodec{},
+				csv:   "one,three\nincorrect,2006-01-02\n42,incorrect\n",
+				data:  &[]testData{},
+			},
+			wantData: &[]testData{
+				{
+					FieldThree: models.Date(time.Date(2006, time.January, 2, 0, 0, 0, 0, time.UTC)),
+				},
+				{FieldOne: 42},
+			},
+			wantErr: func(t assert.TestingT, err error, msgAndArgs ...interface{}) bool {
+				validationErr, ok := err.(*ValidationError)
+				if !assert.True(t, ok, msgAndArgs...) {
+					return false
+				}
+
+				return assert.Len(t, validationErr.Fields, 2, msgAndArgs...) &&
+					assert.Equal(t, "0.one", validationErr.Fields[0].Field, msgAndArgs...) &&
+					assert.Equal(t, "1.three", validationErr.Fields[1].Field, msgAndArgs...)
+			},
+		},
+		{
+			name: "error with a non-pointer",
+			args: args{
+				codec: CSVCodec{},
+				csv:   "one\n23\n",
+				data:  []testData{},
+			},
+			wantData: []testData{},
+			wantErr:  assert.Error,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.args.codec.Decode(strings.NewReader(tt.args.csv), tt.args.data)
+
+			assert.Equal(t, tt.wantData, tt.args.data)
+			tt.wantErr(t, err)
+		})
+	}
+}
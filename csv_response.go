@@ -0,0 +1,225 @@
+package httputils
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"reflect"
+)
+
+const csvBOM = "\uFEFF"
+
+// CSVOptions ...
+type CSVOptions struct {
+	Filename   string
+	Delimiter  rune
+	WithBOM    bool
+	Status     int
+	FlushEvery int
+}
+
+// WriteCSV ...
+func WriteCSV(
+	writer http.ResponseWriter,
+	request *http.Request,
+	logger Logger,
+	options CSVOptions,
+	data interface{},
+) {
+	dataValue := reflect.ValueOf(data)
+	if dataValue.Kind() != reflect.Slice && dataValue.Kind() != reflect.Array {
+		err := errors.New("data should be a slice of structs")
+		writeCSVError(writer, request, logger, err)
+
+		return
+	}
+
+	columns, err := getCSVColumns(dataValue.Type().Elem())
+	if err != nil {
+		writeCSVError(writer, request, logger, err)
+		return
+	}
+
+	streamCSVRecords(
+		writer,
+		request,
+		logger,
+		options,
+		columns,
+		SliceRecordIterator(data),
+	)
+}
+
+// StreamCSVRecords ...
+//
+// The header is made from the type of the first record,
+// so nothing is written for an empty iterator.
+// All the records should have the same type.
+func StreamCSVRecords(
+	writer http.ResponseWriter,
+	request *http.Request,
+	logger Logger,
+	options CSVOptions,
+	iterator RecordIterator,
+) {
+	streamCSVRecords(writer, request, logger, options, nil, iterator)
+}
+
+func streamCSVRecords(
+	writer http.ResponseWriter,
+	request *http.Request,
+	logger Logger,
+	options CSVOptions,
+	columns []csvColumn,
+	iterator RecordIterator,
+) {
+	status := options.Status
+	if status == 0 {
+		status = http.StatusOK
+	}
+
+	writer.Header().Set("Content-Type", CSVMediaType+"; charset=utf-8")
+	if options.Filename != "" {
+		contentDisposition := mime.FormatMediaType(
+			"attachment",
+			map[string]string{"filename": options.Filename},
+		)
+		writer.Header().Set("Content-Disposition", contentDisposition)
+	}
+	writer.WriteHeader(status)
+
+	if options.WithBOM {
+		if _, err := io.WriteString(writer, csvBOM); err != nil {
+			logCSVStreamingError(request, logger, err)
+			return
+		}
+	}
+
+	err := writeCSVRecords(
+		request.Context(),
+		writer,
+		options.Delimiter,
+		options.FlushEvery,
+		columns,
+		iterator,
+	)
+	if err != nil {
+		logCSVStreamingError(request, logger, err)
+	}
+}
+
+func writeCSVRecords(
+	ctx context.Context,
+	writer io.Writer,
+	delimiter rune,
+	flushEvery int,
+	columns []csvColumn,
+	iterator RecordIterator,
+) error {
+	csvWriter := csv.NewWriter(writer)
+	if delimiter != 0 {
+		csvWriter.Comma = delimiter
+	}
+
+	flusher, _ := writer.(http.Flusher)
+	flush := func() error {
+		csvWriter.Flush()
+		if err := csvWriter.Error(); err != nil {
+			return err
+		}
+
+		if flusher != nil {
+			flusher.Flush()
+		}
+
+		return nil
+	}
+
+	if columns != nil {
+		if err := csvWriter.Write(getCSVHeader(columns)); err != nil {
+			return fmt.Errorf("unable to write the header: %w", err)
+		}
+	}
+
+	var recordType reflect.Type
+	for index := 0; ; index++ {
+		if err := ctx.Err(); err != nil {
+			return fmt.Errorf("request was cancelled: %w", err)
+		}
+
+		record, ok, err := iterator(ctx)
+		if err != nil {
+			return fmt.Errorf("unable to get the record #%d: %w", index, err)
+		}
+		if !ok {
+			break
+		}
+
+		recordValue := reflect.ValueOf(record)
+		if recordType == nil {
+			recordType = recordValue.Type()
+		} else if recordValue.Type() != recordType {
+			return fmt.Errorf(
+				"record #%d has the type %s instead of %s",
+				index,
+				recordValue.Type(),
+				recordType,
+			)
+		}
+
+		if columns == nil {
+			if columns, err = getCSVColumns(recordType); err != nil {
+				return err
+			}
+
+			if err := csvWriter.Write(getCSVHeader(columns)); err != nil {
+				return fmt.Errorf("unable to write the header: %w", err)
+			}
+		}
+
+		row, err := makeCSVRow(columns, recordValue)
+		if err != nil {
+			return fmt.Errorf("unable to make the row #%d: %w", index, err)
+		}
+
+		if err := csvWriter.Write(row); err != nil {
+			return fmt.Errorf("unable to write the row #%d: %w", index, err)
+		}
+
+		if flushEvery > 0 && (index+1)%flushEvery == 0 {
+			if err := flush(); err != nil {
+				return fmt.Errorf("unable to flush the rows: %w", err)
+			}
+		}
+	}
+
+	if err := flush(); err != nil {
+		return fmt.Errorf("unable to flush the rows: %w", err)
+	}
+
+	return nil
+}
+
+func writeCSVError(
+	writer http.ResponseWriter,
+	request *http.Request,
+	logger Logger,
+	err error,
+) {
+	status, message :=
+		http.StatusInternalServerError, "unable to make the CSV header"
+	ErrorWriter{Logger: logger}.WriteError(writer, request, status, message, err)
+}
+
+func logCSVStreamingError(request *http.Request, logger Logger, err error) {
+	logger.Print(fmt.Sprintf(
+		"%s %s: unable to stream the records: %s",
+		request.Method,
+		request.URL,
+		err,
+	))
+}
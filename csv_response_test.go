@@ -0,0 +1,222 @@
+package httputils
+
+import (
+	"bytes"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/irenicaa/go-http-utils/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWriteCSV(t *testing.T) {
+	type testData struct {
+		FieldOne int         `csv:"one"`
+		FieldTwo models.Date `csv:"two"`
+	}
+	type args struct {
+		logger  Logger
+		options CSVOptions
+		data    interface{}
+	}
+
+	tests := []struct {
+		name         string
+		args         args
+		wantResponse *http.Response
+	}{
+		{
+			name: "success",
+			args: args{
+				logger: &MockLogger{},
+				options: CSVOptions{
+					Filename:  "report.csv",
+					Delimiter: ';',
+					WithBOM:   true,
+				},
+				data: []testData{
+					{
+						FieldOne: 23,
+						FieldTwo: models.Date(time.Date(2006, time.January, 2, 0, 0, 0, 0, time.UTC)),
+					},
+				},
+			},
+			wantResponse: &http.Response{
+				Status: strconv.Itoa(http.StatusOK) + " " +
+					http.StatusText(http.StatusOK),
+				StatusCode: http.StatusOK,
+				Proto:      "HTTP/1.1",
+				ProtoMajor: 1,
+				ProtoMinor: 1,
+				Header: http.Header{
+					"Content-Type":        {"text/csv; charset=utf-8"},
+					"Content-Disposition": {"attachment; filename=report.csv"},
+				},
+				Body: ioutil.NopCloser(bytes.NewReader(
+					[]byte("\uFEFFone;two\n23;2006-01-02\n"),
+				)),
+				ContentLength: -1,
+			},
+		},
+		{
+			name: "success with an empty slice",
+			args: args{
+				logger:  &MockLogger{},
+				options: CSVOptions{Status: http.StatusPartialContent},
+				data:    []testData{},
+			},
+			wantResponse: &http.Response{
+				Status: strconv.Itoa(http.StatusPartialContent) + " " +
+					http.StatusText(http.StatusPartialContent),
+				StatusCode: http.StatusPartialContent,
+				Proto:      "HTTP/1.1",
+				ProtoMajor: 1,
+				ProtoMinor: 1,
+				Header:     http.Header{"Content-Type": {"text/csv; charset=utf-8"}},
+				Body: ioutil.NopCloser(bytes.NewReader(
+					[]byte("one,two\n"),
+				)),
+				ContentLength: -1,
+			},
+		},
+		{
+			name: "error with the data type",
+			args: args{
+				logger: func() Logger {
+					logger := &MockLogger{}
+					logger.InnerMock.
+						On("Print", []interface{}{
+							"GET http://example.com/test: " +
+								"500 unable to make the CSV header: " +
+								"data should be a slice of structs",
+						}).
+						Return().
+						Times(1)
+
+					return logger
+				}(),
+				options: CSVOptions{},
+				data:    []int{23, 42},
+			},
+			wantResponse: &http.Response{
+				Status: strconv.Itoa(http.StatusInternalServerError) + " " +
+					http.StatusText(http.StatusInternalServerError),
+				StatusCode: http.StatusInternalServerError,
+				Proto:      "HTTP/1.1",
+				ProtoMajor: 1,
+				ProtoMinor: 1,
+				Header:     http.Header{},
+				Body: ioutil.NopCloser(bytes.NewReader(
+					[]byte("unable to make the CSV header"),
+				)),
+				ContentLength: -1,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			request := httptest.NewRequest(http.MethodGet, "http://example.com/test", nil)
+
+			responseRecorder := httptest.NewRecorder()
+			WriteCSV(
+				responseRecorder,
+				request,
+				tt.args.logger,
+				tt.args.options,
+				tt.args.data,
+			)
+
+			tt.args.logger.(*MockLogger).InnerMock.AssertExpectations(t)
+			assert.Equal(t, tt.wantResponse, responseRecorder.Result())
+		})
+	}
+}
+
+func TestStreamCSVRecords(t *testing.T) {
+	type testData struct {
+		FieldOne int `csv:"one"`
+	}
+	type args struct {
+		logger   Logger
+		options  CSVOptions
+		iterator RecordIterator
+	}
+
+	tests := []struct {
+		name        string
+		args        args
+		wantBody    string
+		wantFlushed bool
+	}{
+		{
+			name: "success",
+			args: args{
+				logger:  &MockLogger{},
+				options: CSVOptions{FlushEvery: 1},
+				iterator: func() RecordIterator {
+					records := make(chan testData, 2)
+					records <- testData{FieldOne: 23}
+					records <- testData{FieldOne: 42}
+					close(records)
+
+					return ChannelRecordIterator(records)
+				}(),
+			},
+			wantBody:    "one\n23\n42\n",
+			wantFlushed: true,
+		},
+		{
+			name: "success with an empty iterator",
+			args: args{
+				logger:   &MockLogger{},
+				options:  CSVOptions{},
+				iterator: SliceRecordIterator([]testData{}),
+			},
+			wantBody:    "",
+			wantFlushed: true,
+		},
+		{
+			name: "error with different types",
+			args: args{
+				logger: func() Logger {
+					logger := &MockLogger{}
+					logger.InnerMock.
+						On("Print", []interface{}{
+							"GET http://example.com/test: unable to stream the records: " +
+								"record #1 has the type int instead of httputils.testData",
+						}).
+						Return().
+						Times(1)
+
+					return logger
+				}(),
+				options:  CSVOptions{},
+				iterator: SliceRecordIterator([]interface{}{testData{FieldOne: 23}, 42}),
+			},
+			wantBody:    "",
+			wantFlushed: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			request := httptest.NewRequest(http.MethodGet, "http://example.com/test", nil)
+
+			responseRecorder := httptest.NewRecorder()
+			StreamCSVRecords(
+				responseRecorder,
+				request,
+				tt.args.logger,
+				tt.args.options,
+				tt.args.iterator,
+			)
+
+			tt.args.logger.(*MockLogger).InnerMock.AssertExpectations(t)
+			assert.Equal(t, tt.wantBody, responseRecorder.Body.String())
+			assert.Equal(t, tt.wantFlushed, responseRecorder.Flushed)
+		})
+	}
+}
@@ -0,0 +1,127 @@
+package httputils
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+// ...
+const (
+	CorrelationIDHeader        = "X-Correlation-Id"
+	DefaultPublicErrorMessage  = "internal server error"
+	defaultCorrelationIDLength = 16
+)
+
+// ErrorWriter ...
+type ErrorWriter struct {
+	Logger            Logger
+	ProductionMode    bool
+	MakeCorrelationID func() string
+}
+
+// WriteError ...
+func (errorWriter ErrorWriter) WriteError(
+	writer http.ResponseWriter,
+	request *http.Request,
+	status int,
+	publicMessage string,
+	cause error,
+) {
+	details := fmt.Sprintf("%d %s", status, publicMessage)
+	if request != nil {
+		details = fmt.Sprintf("%s %s: %s", request.Method, request.URL, details)
+	}
+	if cause != nil {
+		details += ": " + cause.Error()
+	}
+
+	if errorWriter.ProductionMode && status >= http.StatusInternalServerError {
+		correlationID := errorWriter.makeCorrelationID()
+		details += fmt.Sprintf(" (correlation ID: %s)", correlationID)
+		publicMessage = fmt.Sprintf(
+			"%s (correlation ID: %s)",
+			DefaultPublicErrorMessage,
+			correlationID,
+		)
+
+		writer.Header().Set(CorrelationIDHeader, correlationID)
+	}
+
+	errorWriter.Logger.Print(details)
+
+	writer.WriteHeader(status)
+	writer.Write([]byte(publicMessage))
+}
+
+// WriteHTTPError ...
+func (errorWriter ErrorWriter) WriteHTTPError(
+	writer http.ResponseWriter,
+	request *http.Request,
+	err error,
+) {
+	var httpErr *HTTPError
+	if errors.As(err, &httpErr) {
+		cause := err
+		if err == error(httpErr) {
+			cause = httpErr.Cause
+		}
+
+		status, publicMessage := ErrorStatus(httpErr), httpErr.PublicMessage()
+		errorWriter.WriteError(writer, request, status, publicMessage, cause)
+
+		return
+	}
+
+	var validationErr *ValidationError
+	if errors.As(err, &validationErr) {
+		HandleValidationError(writer, errorWriter.Logger, validationErr)
+		return
+	}
+
+	status, publicMessage, cause := ErrorStatus(err), err.Error(), error(nil)
+	var responseErr ResponseError
+	var problem Problem
+	switch {
+	case errors.As(err, &responseErr):
+		publicMessage, cause = http.StatusText(status), err
+	case errors.As(err, &problem):
+		publicMessage, cause = problem.Title, err
+		if problem.Detail != "" {
+			publicMessage = problem.Detail
+		}
+	case status >= http.StatusInternalServerError:
+		publicMessage, cause = http.StatusText(status), err
+	}
+
+	errorWriter.WriteError(writer, request, status, publicMessage, cause)
+}
+
+// HandleErrors ...
+func (errorWriter ErrorWriter) HandleErrors(
+	handler HandlerFuncWithError,
+) http.Handler {
+	return http.HandlerFunc(func(
+		writer http.ResponseWriter,
+		request *http.Request,
+	) {
+		if err := handler(writer, request); err != nil {
+			errorWriter.WriteHTTPError(writer, request, err)
+		}
+	})
+}
+
+func (errorWriter ErrorWriter) makeCorrelationID() string {
+	if errorWriter.MakeCorrelationID != nil {
+		return errorWriter.MakeCorrelationID()
+	}
+
+	correlationIDBytes := make([]byte, defaultCorrelationIDLength)
+	if _, err := rand.Read(correlationIDBytes); err != nil {
+		return "unknown"
+	}
+
+	return hex.EncodeToString(correlationIDBytes)
+}
@@ -0,0 +1,230 @@
+package httputils
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestErrorWriter_WriteHTTPError(t *testing.T) {
+	type fields struct {
+		Logger            Logger
+		ProductionMode    bool
+		MakeCorrelationID func() string
+	}
+	type args struct {
+		err error
+	}
+
+	tests := []struct {
+		name         string
+		fields       fields
+		args         args
+		wantResponse *http.Response
+	}{
+		{
+			name: "with an HTTP error with a cause",
+			fields: fields{
+				Logger: func() Logger {
+					logger := &MockLogger{}
+					logger.InnerMock.
+						On("Print", []interface{}{
+							"GET http://example.com/test: " +
+								"404 todo_not_found: todo was not found: sql: no rows",
+						}).
+						Return().
+						Times(1)
+
+					return logger
+				}(),
+			},
+			args: args{
+				err: NewHTTPError(
+					http.StatusNotFound,
+					"todo was not found",
+					errors.New("sql: no rows"),
+				).WithCode("todo_not_found"),
+			},
+			wantResponse: &http.Response{
+				Status: strconv.Itoa(http.StatusNotFound) + " " +
+					http.StatusText(http.StatusNotFound),
+				StatusCode: http.StatusNotFound,
+				Proto:      "HTTP/1.1",
+				ProtoMajor: 1,
+				ProtoMinor: 1,
+				Header:     http.Header{},
+				Body: ioutil.NopCloser(bytes.NewReader(
+					[]byte("todo_not_found: todo was not found"),
+				)),
+				ContentLength: -1,
+			},
+		},
+		{
+			name: "with a wrapped HTTP error",
+			fields: fields{
+				Logger: func() Logger {
+					logger := &MockLogger{}
+					logger.InnerMock.
+						On("Print", []interface{}{
+							"GET http://example.com/test: 409 todo already exists: " +
+								"unable to create the todo: todo already exists",
+						}).
+						Return().
+						Times(1)
+
+					return logger
+				}(),
+			},
+			args: args{
+				err: fmt.Errorf(
+					"unable to create the todo: %w",
+					NewHTTPError(http.StatusConflict, "todo already exists", nil),
+				),
+			},
+			wantResponse: &http.Response{
+				Status: strconv.Itoa(http.StatusConflict) + " " +
+					http.StatusText(http.StatusConflict),
+				StatusCode: http.StatusConflict,
+				Proto:      "HTTP/1.1",
+				ProtoMajor: 1,
+				ProtoMinor: 1,
+				Header:     http.Header{},
+				Body: ioutil.NopCloser(bytes.NewReader(
+					[]byte("todo already exists"),
+				)),
+				ContentLength: -1,
+			},
+		},
+		{
+			name: "with an upstream problem",
+			fields: fields{
+				Logger: func() Logger {
+					logger := &MockLogger{}
+					logger.InnerMock.
+						On("Print", []interface{}{
+							"GET http://example.com/test: 502 Bad Gateway: " +
+								"unable to call the billing: request was failed: " +
+								"problem 401 Unauthorized: token is expired",
+						}).
+						Return().
+						Times(1)
+
+					return logger
+				}(),
+			},
+			args: args{
+				err: fmt.Errorf("unable to call the billing: %w", ResponseError{
+					StatusCode: http.StatusUnauthorized,
+					Problem: &Problem{
+						Title:  "Unauthorized",
+						Status: http.StatusUnauthorized,
+						Detail: "token is expired",
+					},
+				}),
+			},
+			wantResponse: &http.Response{
+				Status: strconv.Itoa(http.StatusBadGateway) + " " +
+					http.StatusText(http.StatusBadGateway),
+				StatusCode: http.StatusBadGateway,
+				Proto:      "HTTP/1.1",
+				ProtoMajor: 1,
+				ProtoMinor: 1,
+				Header:     http.Header{},
+				Body: ioutil.NopCloser(bytes.NewReader(
+					[]byte(http.StatusText(http.StatusBadGateway)),
+				)),
+				ContentLength: -1,
+			},
+		},
+		{
+			name: "with the production mode",
+			fields: fields{
+				Logger: func() Logger {
+					logger := &MockLogger{}
+					logger.InnerMock.
+						On("Print", []interface{}{
+							"GET http://example.com/test: 500 Internal Server Error: " +
+								"unable to connect to the database (correlation ID: 23)",
+						}).
+						Return().
+						Times(1)
+
+					return logger
+				}(),
+				ProductionMode:    true,
+				MakeCorrelationID: func() string { return "23" },
+			},
+			args: args{
+				err: errors.New("unable to connect to the database"),
+			},
+			wantResponse: &http.Response{
+				Status: strconv.Itoa(http.StatusInternalServerError) + " " +
+					http.StatusText(http.StatusInternalServerError),
+				StatusCode: http.StatusInternalServerError,
+				Proto:      "HTTP/1.1",
+				ProtoMajor: 1,
+				ProtoMinor: 1,
+				Header:     http.Header{CorrelationIDHeader: {"23"}},
+				Body: ioutil.NopCloser(bytes.NewReader(
+					[]byte("internal server error (correlation ID: 23)"),
+				)),
+				ContentLength: -1,
+			},
+		},
+		{
+			name: "with the production mode and a client error",
+			fields: fields{
+				Logger: func() Logger {
+					logger := &MockLogger{}
+					logger.InnerMock.
+						On("Print", []interface{}{
+							"GET http://example.com/test: 400 key is missed",
+						}).
+						Return().
+						Times(1)
+
+					return logger
+				}(),
+				ProductionMode:    true,
+				MakeCorrelationID: func() string { return "23" },
+			},
+			args: args{
+				err: ErrKeyIsMissed,
+			},
+			wantResponse: &http.Response{
+				Status: strconv.Itoa(http.StatusBadRequest) + " " +
+					http.StatusText(http.StatusBadRequest),
+				StatusCode:    http.StatusBadRequest,
+				Proto:         "HTTP/1.1",
+				ProtoMajor:    1,
+				ProtoMinor:    1,
+				Header:        http.Header{},
+				Body:          ioutil.NopCloser(bytes.NewReader([]byte("key is missed"))),
+				ContentLength: -1,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			responseRecorder := httptest.NewRecorder()
+			request := httptest.NewRequest(http.MethodGet, "http://example.com/test", nil)
+
+			errorWriter := ErrorWriter{
+				Logger:            tt.fields.Logger,
+				ProductionMode:    tt.fields.ProductionMode,
+				MakeCorrelationID: tt.fields.MakeCorrelationID,
+			}
+			errorWriter.WriteHTTPError(responseRecorder, request, tt.args.err)
+
+			tt.fields.Logger.(*MockLogger).InnerMock.AssertExpectations(t)
+			assert.Equal(t, tt.wantResponse, responseRecorder.Result())
+		})
+	}
+}
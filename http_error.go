@@ -0,0 +1,120 @@
+package httputils
+
+import (
+	"errors"
+	"net/http"
+)
+
+// HTTPError ...
+type HTTPError struct {
+	Status  int
+	Message string
+	Cause   error
+	Code    string
+}
+
+// NewHTTPError ...
+func NewHTTPError(status int, message string, cause error) *HTTPError {
+	return &HTTPError{Status: status, Message: message, Cause: cause}
+}
+
+// WithCode ...
+func (err *HTTPError) WithCode(code string) *HTTPError {
+	errCopy := *err
+	errCopy.Code = code
+
+	return &errCopy
+}
+
+// PublicMessage ...
+func (err *HTTPError) PublicMessage() string {
+	message := err.Message
+	if message == "" {
+		message = http.StatusText(err.Status)
+	}
+	if err.Code != "" {
+		message = err.Code + ": " + message
+	}
+
+	return message
+}
+
+// Error ...
+func (err *HTTPError) Error() string {
+	message := err.PublicMessage()
+	if err.Cause != nil {
+		message += ": " + err.Cause.Error()
+	}
+
+	return message
+}
+
+// Unwrap ...
+func (err *HTTPError) Unwrap() error {
+	return err.Cause
+}
+
+// ErrorStatus ...
+func ErrorStatus(err error) int {
+	var httpErr *HTTPError
+	if errors.As(err, &httpErr) && httpErr.Status != 0 {
+		return httpErr.Status
+	}
+
+	// the problem of an upstream response describes the failure
+	// of another service, not the one of the current request
+	var responseErr ResponseError
+	if errors.As(err, &responseErr) {
+		return http.StatusBadGateway
+	}
+
+	var problem Problem
+	if errors.As(err, &problem) && problem.Status != 0 {
+		return problem.Status
+	}
+
+	if errors.Is(err, ErrBodyTooLarge) {
+		return http.StatusRequestEntityTooLarge
+	}
+	if errors.Is(err, ErrUnsupportedContentType) {
+		return http.StatusUnsupportedMediaType
+	}
+	if errors.Is(err, ErrNotAcceptable) {
+		return http.StatusNotAcceptable
+	}
+	if errors.Is(err, ErrDeadlineExceeded) {
+		return http.StatusGatewayTimeout
+	}
+	if errors.Is(err, ErrTransportFailure) {
+		return http.StatusBadGateway
+	}
+
+	var validationErr *ValidationError
+	if errors.As(err, &validationErr) ||
+		errors.Is(err, ErrInvalidJSONData) ||
+		errors.Is(err, ErrKeyIsMissed) ||
+		errors.Is(err, ErrParameterIsMissed) ||
+		errors.Is(err, ErrValueTooLess) ||
+		errors.Is(err, ErrValueTooGreater) ||
+		errors.Is(err, ErrValueIsNotAllowed) {
+		return http.StatusBadRequest
+	}
+
+	return http.StatusInternalServerError
+}
+
+// HandleHTTPError ...
+func HandleHTTPError(writer http.ResponseWriter, logger Logger, err error) {
+	ErrorWriter{Logger: logger}.WriteHTTPError(writer, nil, err)
+}
+
+// HandlerFuncWithError ...
+type HandlerFuncWithError func(
+	writer http.ResponseWriter,
+	request *http.Request,
+) error
+
+// HandleErrors ...
+func HandleErrors(handler HandlerFuncWithError, logger Logger) http.Handler {
+	return ErrorWriter{Logger: logger}.HandleErrors(handler)
+}
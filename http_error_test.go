@@ -0,0 +1,279 @@
+package httputils
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHTTPError_Error(t *testing.T) {
+	tests := []struct {
+		name    string
+		httpErr *HTTPError
+		want    string
+	}{
+		{
+			name:    "with a message",
+			httpErr: NewHTTPError(http.StatusNotFound, "todo was not found", nil),
+			want:    "todo was not found",
+		},
+		{
+			name:    "without a message",
+			httpErr: NewHTTPError(http.StatusNotFound, "", nil),
+			want:    "Not Found",
+		},
+		{
+			name: "with a code and a cause",
+			httpErr: NewHTTPError(
+				http.StatusNotFound,
+				"todo was not found",
+				errors.New("sql: no rows"),
+			).WithCode("todo_not_found"),
+			want: "todo_not_found: todo was not found: sql: no rows",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.httpErr.Error()
+
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestErrorStatus(t *testing.T) {
+	type args struct {
+		err error
+	}
+
+	tests := []struct {
+		name string
+		args args
+		want int
+	}{
+		{
+			name: "with a wrapped HTTP error",
+			args: args{
+				err: fmt.Errorf(
+					"unable to get the todo: %w",
+					NewHTTPError(http.StatusNotFound, "todo was not found", nil),
+				),
+			},
+			want: http.StatusNotFound,
+		},
+		{
+			name: "with a problem",
+			args: args{err: Problem{Status: http.StatusConflict}},
+			want: http.StatusConflict,
+		},
+		{
+			name: "with a wrapped upstream problem",
+			args: args{
+				err: fmt.Errorf("unable to call the billing: %w", ResponseError{
+					StatusCode: http.StatusUnauthorized,
+					Problem:    &Problem{Status: http.StatusUnauthorized},
+				}),
+			},
+			want: http.StatusBadGateway,
+		},
+		{
+			name: "with a wrapped missed key",
+			args: args{err: fmt.Errorf("unable to get the limit: %w", ErrKeyIsMissed)},
+			want: http.StatusBadRequest,
+		},
+		{
+			name: "with an exceeded deadline",
+			args: args{
+				err: fmt.Errorf("unable to send the request: %w", ErrDeadlineExceeded),
+			},
+			want: http.StatusGatewayTimeout,
+		},
+		{
+			name: "with a transport failure",
+			args: args{
+				err: fmt.Errorf("unable to send the request: %w", ErrTransportFailure),
+			},
+			want: http.StatusBadGateway,
+		},
+		{
+			name: "with a validation error",
+			args: args{
+				err: func() error {
+					validationErr := &ValidationError{}
+					validationErr.AddError("limit", errors.New("incorrect"))
+
+					return validationErr
+				}(),
+			},
+			want: http.StatusBadRequest,
+		},
+		{
+			name: "with a wrapped non-acceptable error",
+			args: args{err: fmt.Errorf("%w: %q", ErrNotAcceptable, "text/plain")},
+			want: http.StatusNotAcceptable,
+		},
+		{
+			name: "with an unknown error",
+			args: args{err: errors.New("unknown")},
+			want: http.StatusInternalServerError,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ErrorStatus(tt.args.err)
+
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestHandleErrors(t *testing.T) {
+	type args struct {
+		handler HandlerFuncWithError
+		logger  Logger
+	}
+
+	tests := []struct {
+		name         string
+		args         args
+		wantResponse *http.Response
+	}{
+		{
+			name: "success",
+			args: args{
+				handler: func(writer http.ResponseWriter, request *http.Request) error {
+					writer.Write([]byte("Hello, world!"))
+					return nil
+				},
+				logger: &MockLogger{},
+			},
+			wantResponse: &http.Response{
+				Status: strconv.Itoa(http.StatusOK) + " " +
+					http.StatusText(http.StatusOK),
+				StatusCode: http.StatusOK,
+				Proto:      "HTTP/1.1",
+				ProtoMajor: 1,
+				ProtoMinor: 1,
+				Header: http.Header{
+					"Content-Type": {"text/plain; charset=utf-8"},
+				},
+				Body:          ioutil.NopCloser(bytes.NewReader([]byte("Hello, world!"))),
+				ContentLength: -1,
+			},
+		},
+		{
+			name: "error with an HTTP error",
+			args: args{
+				handler: func(writer http.ResponseWriter, request *http.Request) error {
+					return NewHTTPError(http.StatusNotFound, "todo was not found", nil)
+				},
+				logger: func() Logger {
+					logger := &MockLogger{}
+					logger.InnerMock.
+						On("Print", []interface{}{
+							"GET http://example.com/test: 404 todo was not found",
+						}).
+						Return().
+						Times(1)
+
+					return logger
+				}(),
+			},
+			wantResponse: &http.Response{
+				Status: strconv.Itoa(http.StatusNotFound) + " " +
+					http.StatusText(http.StatusNotFound),
+				StatusCode:    http.StatusNotFound,
+				Proto:         "HTTP/1.1",
+				ProtoMajor:    1,
+				ProtoMinor:    1,
+				Header:        http.Header{},
+				Body:          ioutil.NopCloser(bytes.NewReader([]byte("todo was not found"))),
+				ContentLength: -1,
+			},
+		},
+		{
+			name: "error with a missed key",
+			args: args{
+				handler: func(writer http.ResponseWriter, request *http.Request) error {
+					return fmt.Errorf("unable to get the limit: %w", ErrKeyIsMissed)
+				},
+				logger: func() Logger {
+					logger := &MockLogger{}
+					logger.InnerMock.
+						On("Print", []interface{}{
+							"GET http://example.com/test: " +
+								"400 unable to get the limit: key is missed",
+						}).
+						Return().
+						Times(1)
+
+					return logger
+				}(),
+			},
+			wantResponse: &http.Response{
+				Status: strconv.Itoa(http.StatusBadRequest) + " " +
+					http.StatusText(http.StatusBadRequest),
+				StatusCode: http.StatusBadRequest,
+				Proto:      "HTTP/1.1",
+				ProtoMajor: 1,
+				ProtoMinor: 1,
+				Header:     http.Header{},
+				Body: ioutil.NopCloser(bytes.NewReader(
+					[]byte("unable to get the limit: key is missed"),
+				)),
+				ContentLength: -1,
+			},
+		},
+		{
+			name: "error with an unknown error",
+			args: args{
+				handler: func(writer http.ResponseWriter, request *http.Request) error {
+					return errors.New("unknown")
+				},
+				logger: func() Logger {
+					logger := &MockLogger{}
+					logger.InnerMock.
+						On("Print", []interface{}{
+							"GET http://example.com/test: 500 Internal Server Error: unknown",
+						}).
+						Return().
+						Times(1)
+
+					return logger
+				}(),
+			},
+			wantResponse: &http.Response{
+				Status: strconv.Itoa(http.StatusInternalServerError) + " " +
+					http.StatusText(http.StatusInternalServerError),
+				StatusCode: http.StatusInternalServerError,
+				Proto:      "HTTP/1.1",
+				ProtoMajor: 1,
+				ProtoMinor: 1,
+				Header:     http.Header{},
+				Body: ioutil.NopCloser(bytes.NewReader(
+					[]byte("Internal Server Error"),
+				)),
+				ContentLength: -1,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			responseRecorder := httptest.NewRecorder()
+			request := httptest.NewRequest(http.MethodGet, "http://example.com/test", nil)
+
+			handler := HandleErrors(tt.args.handler, tt.args.logger)
+			handler.ServeHTTP(responseRecorder, request)
+
+			tt.args.logger.(*MockLogger).InnerMock.AssertExpectations(t)
+			assert.Equal(t, tt.wantResponse, responseRecorder.Result())
+		})
+	}
+}
@@ -0,0 +1,323 @@
+package httputils
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// JSONClient ...
+//
+// Its headers are added to each request;
+// relative request URLs are joined with the base URL.
+// The timeout is used for requests that don't set their own.
+type JSONClient struct {
+	HTTPClient HTTPClient
+	BaseURL    string
+	Headers    http.Header
+	Timeout    time.Duration
+}
+
+// JSONRequest ...
+//
+// The request body is sent only if the request data isn't nil.
+// Any 2xx status is considered successful if success statuses aren't set.
+// The error data receives the body of an unsuccessful response.
+type JSONRequest struct {
+	Method          string
+	URL             string
+	Headers         http.Header
+	Query           url.Values
+	RequestData     interface{}
+	SuccessStatuses []int
+	ResponseData    interface{}
+	ErrorData       interface{}
+	Timeout         time.Duration
+}
+
+// Get ...
+func (client JSONClient) Get(url string, responseData interface{}) error {
+	return client.GetWithContext(context.Background(), url, responseData)
+}
+
+// GetWithContext ...
+func (client JSONClient) GetWithContext(
+	ctx context.Context,
+	url string,
+	responseData interface{},
+) error {
+	_, err := client.DoWithContext(ctx, JSONRequest{
+		Method:       http.MethodGet,
+		URL:          url,
+		ResponseData: responseData,
+	})
+	return err
+}
+
+// Post ...
+func (client JSONClient) Post(
+	url string,
+	requestData interface{},
+	responseData interface{},
+) error {
+	return client.PostWithContext(
+		context.Background(),
+		url,
+		requestData,
+		responseData,
+	)
+}
+
+// PostWithContext ...
+func (client JSONClient) PostWithContext(
+	ctx context.Context,
+	url string,
+	requestData interface{},
+	responseData interface{},
+) error {
+	_, err := client.DoWithContext(ctx, JSONRequest{
+		Method:       http.MethodPost,
+		URL:          url,
+		RequestData:  requestData,
+		ResponseData: responseData,
+	})
+	return err
+}
+
+// Put ...
+func (client JSONClient) Put(
+	url string,
+	requestData interface{},
+	responseData interface{},
+) error {
+	return client.PutWithContext(
+		context.Background(),
+		url,
+		requestData,
+		responseData,
+	)
+}
+
+// PutWithContext ...
+func (client JSONClient) PutWithContext(
+	ctx context.Context,
+	url string,
+	requestData interface{},
+	responseData interface{},
+) error {
+	_, err := client.DoWithContext(ctx, JSONRequest{
+		Method:       http.MethodPut,
+		URL:          url,
+		RequestData:  requestData,
+		ResponseData: responseData,
+	})
+	return err
+}
+
+// Patch ...
+func (client JSONClient) Patch(
+	url string,
+	requestData interface{},
+	responseData interface{},
+) error {
+	return client.PatchWithContext(
+		context.Background(),
+		url,
+		requestData,
+		responseData,
+	)
+}
+
+// PatchWithContext ...
+func (client JSONClient) PatchWithContext(
+	ctx context.Context,
+	url string,
+	requestData interface{},
+	responseData interface{},
+) error {
+	_, err := client.DoWithContext(ctx, JSONRequest{
+		Method:       http.MethodPatch,
+		URL:          url,
+		RequestData:  requestData,
+		ResponseData: responseData,
+	})
+	return err
+}
+
+// Delete ...
+func (client JSONClient) Delete(url string) error {
+	return client.DeleteWithContext(context.Background(), url)
+}
+
+// DeleteWithContext ...
+func (client JSONClient) DeleteWithContext(
+	ctx context.Context,
+	url string,
+) error {
+	_, err := client.DoWithContext(ctx, JSONRequest{
+		Method: http.MethodDelete,
+		URL:    url,
+	})
+	return err
+}
+
+// Do ...
+func (client JSONClient) Do(jsonRequest JSONRequest) (status int, err error) {
+	return client.DoWithContext(context.Background(), jsonRequest)
+}
+
+// DoWithContext ...
+//
+// It returns the status of the response; an unsuccessful one
+// is reported by ResponseError.
+func (client JSONClient) DoWithContext(
+	ctx context.Context,
+	jsonRequest JSONRequest,
+) (status int, err error) {
+	timeout := jsonRequest.Timeout
+	if timeout == 0 {
+		timeout = client.Timeout
+	}
+
+	ctx, cancel := withCallTimeout(ctx, timeout)
+	defer cancel()
+
+	request, err := client.makeRequest(ctx, jsonRequest)
+	if err != nil {
+		return 0, err
+	}
+
+	response, err := client.HTTPClient.Do(request)
+	if err != nil {
+		return 0,
+			fmt.Errorf("unable to send the request: %w", makeCallError(ctx, err))
+	}
+	defer response.Body.Close()
+
+	responseBytes, err := ioutil.ReadAll(response.Body)
+	if err != nil {
+		return response.StatusCode, fmt.Errorf(
+			"unable to read the response body: %w",
+			makeCallError(ctx, err),
+		)
+	}
+
+	if !isSuccessStatus(response.StatusCode, jsonRequest.SuccessStatuses) {
+		if jsonRequest.ErrorData != nil && len(responseBytes) != 0 {
+			// the error body is optional, so its malformation isn't reported;
+			// the raw one is available in ResponseError anyway
+			_ = json.Unmarshal(responseBytes, jsonRequest.ErrorData)
+		}
+
+		return response.StatusCode, makeResponseError(response, responseBytes)
+	}
+
+	if jsonRequest.ResponseData != nil && len(responseBytes) != 0 {
+		err := json.Unmarshal(responseBytes, jsonRequest.ResponseData)
+		if err != nil {
+			return response.StatusCode,
+				fmt.Errorf("unable to unmarshal the response body: %w", err)
+		}
+	}
+
+	return response.StatusCode, nil
+}
+
+func (client JSONClient) makeRequest(
+	ctx context.Context,
+	jsonRequest JSONRequest,
+) (*http.Request, error) {
+	requestURL, err := client.makeURL(jsonRequest.URL, jsonRequest.Query)
+	if err != nil {
+		return nil, err
+	}
+
+	var requestBody io.Reader
+	if jsonRequest.RequestData != nil {
+		requestBytes, err := json.Marshal(jsonRequest.RequestData)
+		if err != nil {
+			return nil, fmt.Errorf("unable to marshal the request data: %w", err)
+		}
+
+		requestBody = bytes.NewReader(requestBytes)
+	}
+
+	request, err := http.NewRequestWithContext(
+		ctx,
+		jsonRequest.Method,
+		requestURL,
+		requestBody,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("unable to create the request: %w", err)
+	}
+
+	for _, headers := range []http.Header{client.Headers, jsonRequest.Headers} {
+		for name, values := range headers {
+			request.Header.Del(name)
+			for _, value := range values {
+				request.Header.Add(name, value)
+			}
+		}
+	}
+	if request.Header.Get("Accept") == "" {
+		request.Header.Set("Accept", JSONMediaType)
+	}
+	if requestBody != nil && request.Header.Get("Content-Type") == "" {
+		request.Header.Set("Content-Type", JSONMediaType)
+	}
+
+	return request, nil
+}
+
+func (client JSONClient) makeURL(rawURL string, query url.Values) (
+	string,
+	error,
+) {
+	parsedURL, err := url.Parse(rawURL)
+	if err != nil {
+		return "", fmt.Errorf("unable to parse the URL: %w", err)
+	}
+
+	if !parsedURL.IsAbs() && client.BaseURL != "" {
+		joinedURL := strings.TrimSuffix(client.BaseURL, "/") +
+			"/" + strings.TrimPrefix(rawURL, "/")
+		if parsedURL, err = url.Parse(joinedURL); err != nil {
+			return "", fmt.Errorf("unable to parse the URL: %w", err)
+		}
+	}
+
+	if len(query) != 0 {
+		mergedQuery := parsedURL.Query()
+		for key, values := range query {
+			for _, value := range values {
+				mergedQuery.Add(key, value)
+			}
+		}
+
+		parsedURL.RawQuery = mergedQuery.Encode()
+	}
+
+	return parsedURL.String(), nil
+}
+
+func isSuccessStatus(status int, successStatuses []int) bool {
+	if len(successStatuses) == 0 {
+		return status >= 200 && status < 300
+	}
+
+	for _, successStatus := range successStatuses {
+		if status == successStatus {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,655 @@
+package httputils
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"testing"
+	"testing/iotest"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestJSONClient_Do(t *testing.T) {
+	type testData struct {
+		FieldOne int
+		FieldTwo string
+	}
+	type fields struct {
+		HTTPClient HTTPClient
+		BaseURL    string
+		Headers    http.Header
+	}
+	type args struct {
+		jsonRequest JSONRequest
+	}
+
+	tests := []struct {
+		name             string
+		fields           fields
+		args             args
+		wantResponseData interface{}
+		wantErrorData    interface{}
+		wantStatus       int
+		wantErr          assert.ErrorAssertionFunc
+	}{
+		{
+			name: "success with the GET method",
+			fields: fields{
+				HTTPClient: func() HTTPClient {
+					response := &http.Response{
+						StatusCode: http.StatusOK,
+						Body: ioutil.NopCloser(bytes.NewReader(
+							[]byte(`{"FieldOne": 23, "FieldTwo": "test"}`),
+						)),
+					}
+
+					httpClient := &MockHTTPClient{}
+					httpClient.InnerMock.
+						On("Do", matchRequest(
+							t,
+							http.MethodGet,
+							"http://example.com/api/v1/todos?page=2&sort=title",
+							http.Header{
+								"Accept":        {"application/json"},
+								"Authorization": {"Bearer token"},
+							},
+							"",
+						)).
+						Return(response, nil).
+						Times(1)
+
+					return httpClient
+				}(),
+				BaseURL: "http://example.com/api/v1/",
+				Headers: http.Header{"Authorization": {"Bearer token"}},
+			},
+			args: args{
+				jsonRequest: JSONRequest{
+					Method:       http.MethodGet,
+					URL:          "/todos?sort=title",
+					Query:        map[string][]string{"page": {"2"}},
+					ResponseData: &testData{},
+				},
+			},
+			wantResponseData: &testData{FieldOne: 23, FieldTwo: "test"},
+			wantStatus:       http.StatusOK,
+			wantErr:          assert.NoError,
+		},
+		{
+			name: "success with the POST method",
+			fields: fields{
+				HTTPClient: func() HTTPClient {
+					response := &http.Response{
+						StatusCode: http.StatusCreated,
+						Body: ioutil.NopCloser(bytes.NewReader(
+							[]byte(`{"FieldOne": 42, "FieldTwo": "test"}`),
+						)),
+					}
+
+					httpClient := &MockHTTPClient{}
+					httpClient.InnerMock.
+						On("Do", matchRequest(
+							t,
+							http.MethodPost,
+							"http://example.com/todos",
+							http.Header{
+								"Accept":       {"application/json"},
+								"Content-Type": {"application/json"},
+								"X-Request-Id": {"23"},
+							},
+							`{"FieldOne":23,"FieldTwo":"test"}`,
+						)).
+						Return(response, nil).
+						Times(1)
+
+					return httpClient
+				}(),
+				BaseURL: "http://another.com/",
+			},
+			args: args{
+				jsonRequest: JSONRequest{
+					Method:          http.MethodPost,
+					URL:             "http://example.com/todos",
+					Headers:         http.Header{"X-Request-Id": {"23"}},
+					RequestData:     testData{FieldOne: 23, FieldTwo: "test"},
+					SuccessStatuses: []int{http.StatusCreated},
+					ResponseData:    &testData{},
+				},
+			},
+			wantResponseData: &testData{FieldOne: 42, FieldTwo: "test"},
+			wantStatus:       http.StatusCreated,
+			wantErr:          assert.NoError,
+		},
+		{
+			name: "success with the DELETE method and without the response body",
+			fields: fields{
+				HTTPClient: func() HTTPClient {
+					response := &http.Response{
+						StatusCode: http.StatusNoContent,
+						Body:       ioutil.NopCloser(bytes.NewReader(nil)),
+					}
+
+					httpClient := &MockHTTPClient{}
+					httpClient.InnerMock.
+						On("Do", matchRequest(
+							t,
+							http.MethodDelete,
+							"http://example.com/todos/23",
+							http.Header{"Accept": {"application/json"}},
+							"",
+						)).
+						Return(response, nil).
+						Times(1)
+
+					return httpClient
+				}(),
+			},
+			args: args{
+				jsonRequest: JSONRequest{
+					Method:       http.MethodDelete,
+					URL:          "http://example.com/todos/23",
+					ResponseData: &testData{},
+				},
+			},
+			wantResponseData: &testData{},
+			wantStatus:       http.StatusNoContent,
+			wantErr:          assert.NoError,
+		},
+		{
+			name: "error with request creating",
+			fields: fields{
+				HTTPClient: &MockHTTPClient{},
+			},
+			args: args{
+				jsonRequest: JSONRequest{
+					Method:       http.MethodGet,
+					URL:          ":",
+					ResponseData: &testData{},
+				},
+			},
+			wantResponseData: &testData{},
+			wantStatus:       0,
+			wantErr:          assert.Error,
+		},
+		{
+			name: "error with request data marshalling",
+			fields: fields{
+				HTTPClient: &MockHTTPClient{},
+			},
+			args: args{
+				jsonRequest: JSONRequest{
+					Method:       http.MethodPost,
+					URL:          "http://example.com/todos",
+					RequestData:  func() {},
+					ResponseData: &testData{},
+				},
+			},
+			wantResponseData: &testData{},
+			wantStatus:       0,
+			wantErr:          assert.Error,
+		},
+		{
+			name: "error with request sending",
+			fields: fields{
+				HTTPClient: func() HTTPClient {
+					httpClient := &MockHTTPClient{}
+					httpClient.InnerMock.
+						On("Do", mock.AnythingOfType("*http.Request")).
+						Return((*http.Response)(nil), iotest.ErrTimeout).
+						Times(1)
+
+					return httpClient
+				}(),
+			},
+			args: args{
+				jsonRequest: JSONRequest{
+					Method:       http.MethodGet,
+					URL:          "http://example.com/todos",
+					ResponseData: &testData{},
+				},
+			},
+			wantResponseData: &testData{},
+			wantStatus:       0,
+			wantErr:          assert.Error,
+		},
+		{
+			name: "error with the reading of the response body",
+			fields: fields{
+				HTTPClient: func() HTTPClient {
+					response := &http.Response{
+						StatusCode: http.StatusOK,
+						Body: ioutil.NopCloser(iotest.TimeoutReader(bytes.NewReader(
+							[]byte(`{"FieldOne": 23, "FieldTwo": "test"}`),
+						))),
+					}
+
+					httpClient := &MockHTTPClient{}
+					httpClient.InnerMock.
+						On("Do", mock.AnythingOfType("*http.Request")).
+						Return(response, nil).
+						Times(1)
+
+					return httpClient
+				}(),
+			},
+			args: args{
+				jsonRequest: JSONRequest{
+					Method:       http.MethodGet,
+					URL:          "http://example.com/todos",
+					ResponseData: &testData{},
+				},
+			},
+			wantResponseData: &testData{},
+			wantStatus:       http.StatusOK,
+			wantErr:          assert.Error,
+		},
+		{
+			name: "error with the response status",
+			fields: fields{
+				HTTPClient: func() HTTPClient {
+					response := &http.Response{
+						StatusCode: http.StatusOK,
+						Body: ioutil.NopCloser(bytes.NewReader(
+							[]byte(`{"FieldOne": 23, "FieldTwo": "test"}`),
+						)),
+					}
+
+					httpClient := &MockHTTPClient{}
+					httpClient.InnerMock.
+						On("Do", mock.AnythingOfType("*http.Request")).
+						Return(response, nil).
+						Times(1)
+
+					return httpClient
+				}(),
+			},
+			args: args{
+				jsonRequest: JSONRequest{
+					Method:          http.MethodPost,
+					URL:             "http://example.com/todos",
+					RequestData:     testData{FieldOne: 23, FieldTwo: "test"},
+					SuccessStatuses: []int{http.StatusCreated},
+					ResponseData:    &testData{},
+				},
+			},
+			wantResponseData: &testData{},
+			wantStatus:       http.StatusOK,
+			wantErr: func(
+				t assert.TestingT,
+				err error,
+				msgAndArgs ...interface{},
+			) bool {
+				wantErr := ResponseError{
+					StatusCode: http.StatusOK,
+					Body:       []byte(`{"FieldOne": 23, "FieldTwo": "test"}`),
+				}
+				return assert.Equal(t, wantErr, err, msgAndArgs...)
+			},
+		},
+		{
+			name: "error with the error data",
+			fields: fields{
+				HTTPClient: func() HTTPClient {
+					response := &http.Response{
+						StatusCode: http.StatusUnprocessableEntity,
+						Body: ioutil.NopCloser(bytes.NewReader(
+							[]byte(`{"FieldOne": 42, "FieldTwo": "error"}`),
+						)),
+					}
+
+					httpClient := &MockHTTPClient{}
+					httpClient.InnerMock.
+						On("Do", mock.AnythingOfType("*http.Request")).
+						Return(response, nil).
+						Times(1)
+
+					return httpClient
+				}(),
+			},
+			args: args{
+				jsonRequest: JSONRequest{
+					Method:       http.MethodPut,
+					URL:          "http://example.com/todos/23",
+					RequestData:  testData{FieldOne: 23, FieldTwo: "test"},
+					ResponseData: &testData{},
+					ErrorData:    &testData{},
+				},
+			},
+			wantResponseData: &testData{},
+			wantErrorData:    &testData{FieldOne: 42, FieldTwo: "error"},
+			wantStatus:       http.StatusUnprocessableEntity,
+			wantErr: func(
+				t assert.TestingT,
+				err error,
+				msgAndArgs ...interface{},
+			) bool {
+				return assert.EqualError(
+					t,
+					err,
+					`request was failed: 422 {"FieldOne": 42, "FieldTwo": "error"}`,
+					msgAndArgs...,
+				)
+			},
+		},
+		{
+			name: "error with the problem response",
+			fields: fields{
+				HTTPClient: func() HTTPClient {
+					response := &http.Response{
+						StatusCode: http.StatusNotFound,
+						Header: http.Header{
+							"Content-Type": {ProblemContentType + "; charset=utf-8"},
+						},
+						Body: ioutil.NopCloser(bytes.NewReader(
+							[]byte(`{"title":"Not Found","detail":"todo was not found"}`),
+						)),
+					}
+
+					httpClient := &MockHTTPClient{}
+					httpClient.InnerMock.
+						On("Do", mock.AnythingOfType("*http.Request")).
+						Return(response, nil).
+						Times(1)
+
+					return httpClient
+				}(),
+			},
+			args: args{
+				jsonRequest: JSONRequest{
+					Method:       http.MethodPatch,
+					URL:          "http://example.com/todos/23",
+					RequestData:  map[string]interface{}{"FieldTwo": "test"},
+					ResponseData: &testData{},
+				},
+			},
+			wantResponseData: &testData{},
+			wantStatus:       http.StatusNotFound,
+			wantErr: func(
+				t assert.TestingT,
+				err error,
+				msgAndArgs ...interface{},
+			) bool {
+				var problem Problem
+				if !assert.True(t, errors.As(err, &problem), msgAndArgs...) {
+					return false
+				}
+
+				wantProblem := Problem{
+					Type:   DefaultProblemType,
+					Title:  "Not Found",
+					Status: http.StatusNotFound,
+					Detail: "todo was not found",
+				}
+				return assert.Equal(t, wantProblem, problem, msgAndArgs...)
+			},
+		},
+		{
+			name: "error with the unmarshalling of the response body",
+			fields: fields{
+				HTTPClient: func() HTTPClient {
+					response := &http.Response{
+						StatusCode: http.StatusOK,
+						Body:       ioutil.NopCloser(bytes.NewReader([]byte("incorrect"))),
+					}
+
+					httpClient := &MockHTTPClient{}
+					httpClient.InnerMock.
+						On("Do", mock.AnythingOfType("*http.Request")).
+						Return(response, nil).
+						Times(1)
+
+					return httpClient
+				}(),
+			},
+			args: args{
+				jsonRequest: JSONRequest{
+					Method:       http.MethodGet,
+					URL:          "http://example.com/todos/23",
+					ResponseData: &testData{},
+				},
+			},
+			wantResponseData: &testData{},
+			wantStatus:       http.StatusOK,
+			wantErr:          assert.Error,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := JSONClient{
+				HTTPClient: tt.fields.HTTPClient,
+				BaseURL:    tt.fields.BaseURL,
+				Headers:    tt.fields.Headers,
+			}
+			gotStatus, err := client.Do(tt.args.jsonRequest)
+
+			tt.fields.HTTPClient.(*MockHTTPClient).InnerMock.AssertExpectations(t)
+			assert.Equal(t, tt.wantResponseData, tt.args.jsonRequest.ResponseData)
+			if tt.wantErrorData != nil {
+				assert.Equal(t, tt.wantErrorData, tt.args.jsonRequest.ErrorData)
+			}
+			assert.Equal(t, tt.wantStatus, gotStatus)
+			tt.wantErr(t, err)
+		})
+	}
+}
+
+func TestJSONClient_methods(t *testing.T) {
+	type testData struct {
+		FieldOne int
+		FieldTwo string
+	}
+
+	tests := []struct {
+		name       string
+		wantMethod string
+		wantBody   string
+		call       func(client JSONClient, responseData *testData) error
+	}{
+		{
+			name:       "Get",
+			wantMethod: http.MethodGet,
+			call: func(client JSONClient, responseData *testData) error {
+				return client.Get("/todos/23", responseData)
+			},
+		},
+		{
+			name:       "Post",
+			wantMethod: http.MethodPost,
+			wantBody:   `{"FieldOne":23,"FieldTwo":"test"}`,
+			call: func(client JSONClient, responseData *testData) error {
+				requestData := testData{FieldOne: 23, FieldTwo: "test"}
+				return client.Post("/todos/23", requestData, responseData)
+			},
+		},
+		{
+			name:       "Put",
+			wantMethod: http.MethodPut,
+			wantBody:   `{"FieldOne":23,"FieldTwo":"test"}`,
+			call: func(client JSONClient, responseData *testData) error {
+				requestData := testData{FieldOne: 23, FieldTwo: "test"}
+				return client.Put("/todos/23", requestData, responseData)
+			},
+		},
+		{
+			name:       "Patch",
+			wantMethod: http.MethodPatch,
+			wantBody:   `{"FieldTwo":"test"}`,
+			call: func(client JSONClient, responseData *testData) error {
+				requestData := map[string]string{"FieldTwo": "test"}
+				return client.Patch("/todos/23", requestData, responseData)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			wantHeaders := http.Header{"Accept": {"application/json"}}
+			if tt.wantBody != "" {
+				wantHeaders.Set("Content-Type", "application/json")
+			}
+
+			response := &http.Response{
+				StatusCode: http.StatusOK,
+				Body: ioutil.NopCloser(bytes.NewReader(
+					[]byte(`{"FieldOne": 23, "FieldTwo": "test"}`),
+				)),
+			}
+
+			httpClient := &MockHTTPClient{}
+			httpClient.InnerMock.
+				On("Do", matchRequest(
+					t,
+					tt.wantMethod,
+					"http://example.com/todos/23",
+					wantHeaders,
+					tt.wantBody,
+				)).
+				Return(response, nil).
+				Times(1)
+
+			client := JSONClient{
+				HTTPClient: httpClient,
+				BaseURL:    "http://example.com",
+			}
+			var responseData testData
+			err := tt.call(client, &responseData)
+
+			httpClient.InnerMock.AssertExpectations(t)
+			assert.Equal(t, testData{FieldOne: 23, FieldTwo: "test"}, responseData)
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestJSONClient_Delete(t *testing.T) {
+	response := &http.Response{
+		StatusCode: http.StatusNoContent,
+		Body:       ioutil.NopCloser(bytes.NewReader(nil)),
+	}
+
+	httpClient := &MockHTTPClient{}
+	httpClient.InnerMock.
+		On("Do", matchRequest(
+			t,
+			http.MethodDelete,
+			"http://example.com/todos/23",
+			http.Header{"Accept": {"application/json"}},
+			"",
+		)).
+		Return(response, nil).
+		Times(1)
+
+	client := JSONClient{HTTPClient: httpClient, BaseURL: "http://example.com"}
+	err := client.Delete("todos/23")
+
+	httpClient.InnerMock.AssertExpectations(t)
+	assert.NoError(t, err)
+}
+
+func TestJSONClient_DoWithContext(t *testing.T) {
+	type fields struct {
+		Timeout time.Duration
+	}
+	type args struct {
+		ctx         context.Context
+		jsonRequest JSONRequest
+	}
+
+	tests := []struct {
+		name    string
+		fields  fields
+		args    args
+		wantErr error
+	}{
+		{
+			name:   "error with the timeout of the client",
+			fields: fields{Timeout: time.Millisecond},
+			args: args{
+				ctx: context.Background(),
+				jsonRequest: JSONRequest{
+					Method: http.MethodGet,
+					URL:    "http://example.com/todos",
+				},
+			},
+			wantErr: ErrDeadlineExceeded,
+		},
+		{
+			name:   "error with the timeout of the request",
+			fields: fields{Timeout: time.Minute},
+			args: args{
+				ctx: context.Background(),
+				jsonRequest: JSONRequest{
+					Method:  http.MethodGet,
+					URL:     "http://example.com/todos",
+					Timeout: time.Millisecond,
+				},
+			},
+			wantErr: ErrDeadlineExceeded,
+		},
+		{
+			name:   "error with the cancelled context",
+			fields: fields{Timeout: 0},
+			args: args{
+				ctx: func() context.Context {
+					ctx, cancel := context.WithCancel(context.Background())
+					cancel()
+
+					return ctx
+				}(),
+				jsonRequest: JSONRequest{
+					Method: http.MethodGet,
+					URL:    "http://example.com/todos",
+				},
+			},
+			wantErr: ErrRequestCancelled,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			httpClient := &MockHTTPClient{}
+			httpClient.InnerMock.
+				On("Do", mock.AnythingOfType("*http.Request")).
+				Run(func(args mock.Arguments) {
+					<-args.Get(0).(*http.Request).Context().Done()
+				}).
+				Return((*http.Response)(nil), errors.New("dummy")).
+				Times(1)
+
+			client := JSONClient{HTTPClient: httpClient, Timeout: tt.fields.Timeout}
+			gotStatus, err := client.DoWithContext(tt.args.ctx, tt.args.jsonRequest)
+
+			httpClient.InnerMock.AssertExpectations(t)
+			assert.Equal(t, 0, gotStatus)
+			assert.True(t, errors.Is(err, tt.wantErr))
+		})
+	}
+}
+
+func matchRequest(
+	t *testing.T,
+	wantMethod string,
+	wantURL string,
+	wantHeaders http.Header,
+	wantBody string,
+) interface{} {
+	return mock.MatchedBy(func(request *http.Request) bool {
+		var body []byte
+		if request.Body != nil {
+			var err error
+			body, err = ioutil.ReadAll(request.Body)
+			require.NoError(t, err)
+
+			request.Body = ioutil.NopCloser(bytes.NewReader(body))
+		}
+
+		return request.Method == wantMethod &&
+			request.URL.String() == wantURL &&
+			assert.ObjectsAreEqual(wantHeaders, request.Header) &&
+			string(body) == wantBody
+	})
+}
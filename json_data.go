@@ -1,6 +1,7 @@
 package httputils
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -34,7 +35,29 @@ func LoadJSONData(
 	authHeader string,
 	responseData interface{},
 ) error {
-	request, err := http.NewRequest(http.MethodGet, url, nil)
+	return LoadJSONDataWithContext(
+		context.Background(),
+		httpClient,
+		url,
+		authHeader,
+		CallOptions{},
+		responseData,
+	)
+}
+
+// LoadJSONDataWithContext ...
+func LoadJSONDataWithContext(
+	ctx context.Context,
+	httpClient HTTPClient,
+	url string,
+	authHeader string,
+	options CallOptions,
+	responseData interface{},
+) error {
+	ctx, cancel := withCallTimeout(ctx, options.Timeout)
+	defer cancel()
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
 	if err != nil {
 		return fmt.Errorf("unable to create the request: %w", err)
 	}
@@ -45,21 +68,20 @@ func LoadJSONData(
 
 	response, err := httpClient.Do(request)
 	if err != nil {
-		return fmt.Errorf("unable to send the request: %w", err)
+		return fmt.Errorf("unable to send the request: %w", makeCallError(ctx, err))
 	}
 	defer response.Body.Close()
 
 	responseBytes, err := ioutil.ReadAll(response.Body)
 	if err != nil {
-		return fmt.Errorf("unable to read the request body: %w", err)
+		return fmt.Errorf(
+			"unable to read the request body: %w",
+			makeCallError(ctx, err),
+		)
 	}
 
 	if response.StatusCode != http.StatusOK {
-		return fmt.Errorf(
-			"request was failed: %d %s",
-			response.StatusCode,
-			responseBytes,
-		)
+		return makeResponseError(response, responseBytes)
 	}
 
 	if err = json.Unmarshal(responseBytes, responseData); err != nil {
@@ -68,3 +90,41 @@ func LoadJSONData(
 
 	return nil
 }
+
+// ResponseError ...
+//
+// It describes a response with an unexpected status;
+// it wraps the problem details if the response contains them.
+type ResponseError struct {
+	StatusCode int
+	Body       []byte
+	Problem    *Problem
+}
+
+// Error ...
+func (err ResponseError) Error() string {
+	if err.Problem != nil {
+		return "request was failed: " + err.Problem.Error()
+	}
+
+	return fmt.Sprintf("request was failed: %d %s", err.StatusCode, err.Body)
+}
+
+// Unwrap ...
+func (err ResponseError) Unwrap() error {
+	if err.Problem == nil {
+		return nil
+	}
+
+	return *err.Problem
+}
+
+func makeResponseError(response *http.Response, responseBytes []byte) error {
+	responseErr :=
+		ResponseError{StatusCode: response.StatusCode, Body: responseBytes}
+	if problem, ok := decodeProblem(response, responseBytes); ok {
+		responseErr.Problem = &problem
+	}
+
+	return responseErr
+}
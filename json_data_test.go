@@ -2,13 +2,17 @@ package httputils
 
 import (
 	"bytes"
+	"context"
+	"errors"
 	"io"
 	"io/ioutil"
 	"net/http"
 	"testing"
 	"testing/iotest"
+	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 )
 
@@ -224,6 +228,48 @@ func TestLoadJSONData(t *testing.T) {
 				return assert.EqualError(t, err, "request was failed: 500 error")
 			},
 		},
+		{
+			name: "error with the problem response",
+			args: args{
+				httpClient: func() HTTPClient {
+					request, err := http.NewRequest(http.MethodGet, "http://example.com/", nil)
+					require.NoError(t, err)
+
+					response := &http.Response{
+						StatusCode: http.StatusNotFound,
+						Header: http.Header{
+							"Content-Type": {ProblemContentType + "; charset=utf-8"},
+						},
+						Body: ioutil.NopCloser(bytes.NewReader(
+							[]byte(`{"title":"Not Found","detail":"todo was not found"}`),
+						)),
+					}
+
+					httpClient := &MockHTTPClient{}
+					httpClient.InnerMock.On("Do", request).Return(response, nil).Times(1)
+
+					return httpClient
+				}(),
+				url:          "http://example.com/",
+				authHeader:   "",
+				responseData: &testData{},
+			},
+			wantResponseData: &testData{},
+			wantErr: func(t assert.TestingT, err error, msgAndArgs ...interface{}) bool {
+				var problem Problem
+				if !assert.True(t, errors.As(err, &problem), msgAndArgs...) {
+					return false
+				}
+
+				wantProblem := Problem{
+					Type:   DefaultProblemType,
+					Title:  "Not Found",
+					Status: http.StatusNotFound,
+					Detail: "todo was not found",
+				}
+				return assert.Equal(t, wantProblem, problem, msgAndArgs...)
+			},
+		},
 		{
 			name: "error with the unmarshalling of the response body",
 			args: args{
@@ -264,3 +310,167 @@ func TestLoadJSONData(t *testing.T) {
 		})
 	}
 }
+
+func TestLoadJSONDataWithContext(t *testing.T) {
+	type testData struct {
+		FieldOne int
+		FieldTwo string
+	}
+	type args struct {
+		ctx          context.Context
+		httpClient   HTTPClient
+		options      CallOptions
+		responseData interface{}
+	}
+
+	makeWaitingHTTPClient := func() HTTPClient {
+		httpClient := &MockHTTPClient{}
+		httpClient.InnerMock.
+			On("Do", mock.AnythingOfType("*http.Request")).
+			Run(func(args mock.Arguments) {
+				<-args.Get(0).(*http.Request).Context().Done()
+			}).
+			Return((*http.Response)(nil), errors.New("dummy")).
+			Times(1)
+
+		return httpClient
+	}
+
+	tests := []struct {
+		name             string
+		args             args
+		wantResponseData interface{}
+		wantErr          error
+	}{
+		{
+			name: "success with the timeout",
+			args: args{
+				ctx: context.Background(),
+				httpClient: func() HTTPClient {
+					response := &http.Response{
+						StatusCode: http.StatusOK,
+						Body: ioutil.NopCloser(bytes.NewReader(
+							[]byte(`{"FieldOne": 23, "FieldTwo": "test"}`),
+						)),
+					}
+
+					httpClient := &MockHTTPClient{}
+					httpClient.InnerMock.
+						On("Do", mock.MatchedBy(func(request *http.Request) bool {
+							_, ok := request.Context().Deadline()
+							return ok
+						})).
+						Return(response, nil).
+						Times(1)
+
+					return httpClient
+				}(),
+				options:      CallOptions{Timeout: time.Minute},
+				responseData: &testData{},
+			},
+			wantResponseData: &testData{FieldOne: 23, FieldTwo: "test"},
+			wantErr:          nil,
+		},
+		{
+			name: "error with the exceeded timeout",
+			args: args{
+				ctx:          context.Background(),
+				httpClient:   makeWaitingHTTPClient(),
+				options:      CallOptions{Timeout: time.Millisecond},
+				responseData: &testData{},
+			},
+			wantResponseData: &testData{},
+			wantErr:          ErrDeadlineExceeded,
+		},
+		{
+			name: "error with the cancelled context",
+			args: args{
+				ctx: func() context.Context {
+					ctx, cancel := context.WithCancel(context.Background())
+					cancel()
+
+					return ctx
+				}(),
+				httpClient:   makeWaitingHTTPClient(),
+				options:      CallOptions{Timeout: time.Minute},
+				responseData: &testData{},
+			},
+			wantResponseData: &testData{},
+			wantErr:          ErrRequestCancelled,
+		},
+		{
+			name: "error with the transport failure",
+			args: args{
+				ctx: context.Background(),
+				httpClient: func() HTTPClient {
+					httpClient := &MockHTTPClient{}
+					httpClient.InnerMock.
+						On("Do", mock.AnythingOfType("*http.Request")).
+						Return((*http.Response)(nil), iotest.ErrTimeout).
+						Times(1)
+
+					return httpClient
+				}(),
+				options:      CallOptions{},
+				responseData: &testData{},
+			},
+			wantResponseData: &testData{},
+			wantErr:          ErrTransportFailure,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := LoadJSONDataWithContext(
+				tt.args.ctx,
+				tt.args.httpClient,
+				"http://example.com/",
+				"",
+				tt.args.options,
+				tt.args.responseData,
+			)
+
+			tt.args.httpClient.(*MockHTTPClient).InnerMock.AssertExpectations(t)
+			assert.Equal(t, tt.wantResponseData, tt.args.responseData)
+			if tt.wantErr != nil {
+				assert.True(t, errors.Is(err, tt.wantErr))
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestResponseError(t *testing.T) {
+	tests := []struct {
+		name        string
+		err         ResponseError
+		wantMessage string
+		wantErr     error
+	}{
+		{
+			name: "without the problem",
+			err: ResponseError{
+				StatusCode: http.StatusInternalServerError,
+				Body:       []byte("error"),
+			},
+			wantMessage: "request was failed: 500 error",
+			wantErr:     nil,
+		},
+		{
+			name: "with the problem",
+			err: ResponseError{
+				StatusCode: http.StatusNotFound,
+				Body:       []byte(`{"title":"Not Found"}`),
+				Problem:    &Problem{Title: "Not Found", Status: http.StatusNotFound},
+			},
+			wantMessage: "request was failed: problem 404 Not Found",
+			wantErr:     Problem{Title: "Not Found", Status: http.StatusNotFound},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.EqualError(t, tt.err, tt.wantMessage)
+			assert.Equal(t, tt.wantErr, tt.err.Unwrap())
+		})
+	}
+}
@@ -0,0 +1,160 @@
+package httputils
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"strings"
+)
+
+// DefaultMaxJSONBodySize ...
+const DefaultMaxJSONBodySize = 1 << 20
+
+// ...
+var (
+	ErrUnsupportedContentType = errors.New("unsupported content type")
+	ErrBodyTooLarge           = errors.New("body too large")
+	ErrInvalidJSONData        = errors.New("invalid JSON data")
+)
+
+// JSONDecodingOptions ...
+type JSONDecodingOptions struct {
+	MaxBodySize           int64
+	DisallowUnknownFields bool
+	UseNumber             bool
+}
+
+// JSONSyntaxError ...
+type JSONSyntaxError struct {
+	Offset int64
+	Line   int
+	Column int
+	Err    error
+}
+
+// Error ...
+func (err JSONSyntaxError) Error() string {
+	return fmt.Sprintf(
+		"%s (offset %d, line %d, column %d)",
+		err.Err,
+		err.Offset,
+		err.Line,
+		err.Column,
+	)
+}
+
+// Unwrap ...
+func (err JSONSyntaxError) Unwrap() error {
+	return err.Err
+}
+
+// Is ...
+func (err JSONSyntaxError) Is(target error) bool {
+	return target == ErrInvalidJSONData
+}
+
+// DecodeJSONRequest ...
+func DecodeJSONRequest(
+	request *http.Request,
+	data interface{},
+	options JSONDecodingOptions,
+) error {
+	contentType := request.Header.Get("Content-Type")
+	if !isJSONContentType(contentType) {
+		return fmt.Errorf("%w: %q", ErrUnsupportedContentType, contentType)
+	}
+
+	return decodeJSON(request.Body, data, options)
+}
+
+func decodeJSON(
+	reader io.Reader,
+	data interface{},
+	options JSONDecodingOptions,
+) error {
+	maxBodySize := options.MaxBodySize
+	if maxBodySize == 0 {
+		maxBodySize = DefaultMaxJSONBodySize
+	}
+
+	body := reader
+	var limitedBody *io.LimitedReader
+	if maxBodySize > 0 {
+		limitedBody = &io.LimitedReader{R: reader, N: maxBodySize + 1}
+		body = limitedBody
+	}
+
+	var consumedBody bytes.Buffer
+	decoder := json.NewDecoder(io.TeeReader(body, &consumedBody))
+	if options.DisallowUnknownFields {
+		decoder.DisallowUnknownFields()
+	}
+	if options.UseNumber {
+		decoder.UseNumber()
+	}
+
+	isBodyTooLarge := func() bool {
+		return limitedBody != nil && limitedBody.N <= 0
+	}
+
+	err := decoder.Decode(data)
+	if err == nil {
+		if _, tokenErr := decoder.Token(); tokenErr != io.EOF {
+			err = fmt.Errorf("%w: trailing data after the value", ErrInvalidJSONData)
+		}
+	}
+	if isBodyTooLarge() {
+		return fmt.Errorf("%w: limit is %d bytes", ErrBodyTooLarge, maxBodySize)
+	}
+	if err != nil {
+		return makeJSONDecodingError(err, consumedBody.Bytes())
+	}
+
+	return nil
+}
+
+func isJSONContentType(contentType string) bool {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return false
+	}
+
+	return mediaType == "application/json" || strings.HasSuffix(mediaType, "+json")
+}
+
+func makeJSONDecodingError(err error, data []byte) error {
+	var offset int64
+	var syntaxErr *json.SyntaxError
+	var unmarshalTypeErr *json.UnmarshalTypeError
+	switch {
+	case errors.As(err, &syntaxErr):
+		offset = syntaxErr.Offset
+	case errors.As(err, &unmarshalTypeErr):
+		offset = unmarshalTypeErr.Offset
+	case errors.Is(err, io.ErrUnexpectedEOF), errors.Is(err, io.EOF):
+		offset = int64(len(data))
+	case errors.Is(err, ErrInvalidJSONData):
+		return err
+	default:
+		return fmt.Errorf("%w: %s", ErrInvalidJSONData, err)
+	}
+
+	if offset > int64(len(data)) {
+		offset = int64(len(data))
+	}
+
+	line, column := 1, 1
+	for _, symbol := range data[:offset] {
+		if symbol == '\n' {
+			line, column = line+1, 1
+		} else {
+			column++
+		}
+	}
+
+	return JSONSyntaxError{Offset: offset, Line: line, Column: column, Err: err}
+}
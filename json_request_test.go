@@ -0,0 +1,156 @@
+package httputils
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDecodeJSONRequest(t *testing.T) {
+	type testData struct {
+		FieldOne int
+		FieldTwo string
+	}
+	type args struct {
+		request *http.Request
+		data    interface{}
+		options JSONDecodingOptions
+	}
+
+	makeRequest := func(contentType string, body string) *http.Request {
+		request := httptest.NewRequest(
+			http.MethodPost,
+			"http://example.com/test",
+			strings.NewReader(body),
+		)
+		request.Header.Set("Content-Type", contentType)
+
+		return request
+	}
+
+	tests := []struct {
+		name     string
+		args     args
+		wantData interface{}
+		wantErr  assert.ErrorAssertionFunc
+	}{
+		{
+			name: "success",
+			args: args{
+				request: makeRequest(
+					"application/json; charset=utf-8",
+					`{"FieldOne": 23, "FieldTwo": "test"}`+"\n",
+				),
+				data:    &testData{},
+				options: JSONDecodingOptions{DisallowUnknownFields: true},
+			},
+			wantData: &testData{FieldOne: 23, FieldTwo: "test"},
+			wantErr:  assert.NoError,
+		},
+		{
+			name: "success with numbers",
+			args: args{
+				request: makeRequest("application/merge-patch+json", `{"FieldOne": 23}`),
+				data:    &map[string]interface{}{},
+				options: JSONDecodingOptions{UseNumber: true},
+			},
+			wantData: &map[string]interface{}{"FieldOne": json.Number("23")},
+			wantErr:  assert.NoError,
+		},
+		{
+			name: "error with a content type",
+			args: args{
+				request: makeRequest("text/plain", `{"FieldOne": 23}`),
+				data:    &testData{},
+			},
+			wantData: &testData{},
+			wantErr: func(t assert.TestingT, err error, msgAndArgs ...interface{}) bool {
+				return assert.ErrorIs(t, err, ErrUnsupportedContentType, msgAndArgs...)
+			},
+		},
+		{
+			name: "error with a body size",
+			args: args{
+				request: makeRequest(
+					"application/json",
+					`{"FieldOne": 23, "FieldTwo": "test"}`,
+				),
+				data:    &testData{},
+				options: JSONDecodingOptions{MaxBodySize: 10},
+			},
+			wantData: &testData{},
+			wantErr: func(t assert.TestingT, err error, msgAndArgs ...interface{}) bool {
+				return assert.ErrorIs(t, err, ErrBodyTooLarge, msgAndArgs...)
+			},
+		},
+		{
+			name: "error with an unknown field",
+			args: args{
+				request: makeRequest("application/json", `{"FieldThree": 23}`),
+				data:    &testData{},
+				options: JSONDecodingOptions{DisallowUnknownFields: true},
+			},
+			wantData: &testData{},
+			wantErr: func(t assert.TestingT, err error, msgAndArgs ...interface{}) bool {
+				return assert.ErrorIs(t, err, ErrInvalidJSONData, msgAndArgs...)
+			},
+		},
+		{
+			name: "error with trailing data",
+			args: args{
+				request: makeRequest("application/json", `{"FieldOne": 23} {}`),
+				data:    &testData{},
+			},
+			wantData: &testData{FieldOne: 23},
+			wantErr: func(t assert.TestingT, err error, msgAndArgs ...interface{}) bool {
+				return assert.ErrorIs(t, err, ErrInvalidJSONData, msgAndArgs...)
+			},
+		},
+		{
+			name: "error with syntax",
+			args: args{
+				request: makeRequest(
+					"application/json",
+					"{\n  \"FieldOne\": 23,\n  \"FieldTwo\": test\n}",
+				),
+				data: &testData{},
+			},
+			wantData: &testData{},
+			wantErr: func(t assert.TestingT, err error, msgAndArgs ...interface{}) bool {
+				var syntaxErr JSONSyntaxError
+				if !assert.True(t, errors.As(err, &syntaxErr), msgAndArgs...) {
+					return false
+				}
+
+				return assert.Equal(t, int64(36), syntaxErr.Offset, msgAndArgs...) &&
+					assert.Equal(t, 3, syntaxErr.Line, msgAndArgs...) &&
+					assert.Equal(t, 17, syntaxErr.Column, msgAndArgs...)
+			},
+		},
+		{
+			name: "error with a type",
+			args: args{
+				request: makeRequest("application/json", `{"FieldOne": "23"}`),
+				data:    &testData{},
+			},
+			wantData: &testData{},
+			wantErr: func(t assert.TestingT, err error, msgAndArgs ...interface{}) bool {
+				var syntaxErr JSONSyntaxError
+				return assert.True(t, errors.As(err, &syntaxErr), msgAndArgs...)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := DecodeJSONRequest(tt.args.request, tt.args.data, tt.args.options)
+
+			assert.Equal(t, tt.wantData, tt.args.data)
+			tt.wantErr(t, err)
+		})
+	}
+}
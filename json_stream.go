@@ -0,0 +1,322 @@
+package httputils
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"reflect"
+)
+
+// NDJSONContentType ...
+const NDJSONContentType = "application/x-ndjson"
+
+// JSONStreamFormat ...
+type JSONStreamFormat int
+
+// ...
+const (
+	NDJSONStreamFormat JSONStreamFormat = iota
+	JSONArrayStreamFormat
+)
+
+// RecordIterator ...
+type RecordIterator func(ctx context.Context) (
+	record interface{},
+	ok bool,
+	err error,
+)
+
+// ChannelRecordIterator ...
+func ChannelRecordIterator(channel interface{}) RecordIterator {
+	channelValue := reflect.ValueOf(channel)
+	if channelValue.Kind() != reflect.Chan ||
+		channelValue.Type().ChanDir()&reflect.RecvDir == 0 {
+		panic("unable to make the record iterator: channel should be receivable")
+	}
+
+	return func(ctx context.Context) (interface{}, bool, error) {
+		chosenIndex, record, ok := reflect.Select([]reflect.SelectCase{
+			{Dir: reflect.SelectRecv, Chan: reflect.ValueOf(ctx.Done())},
+			{Dir: reflect.SelectRecv, Chan: channelValue},
+		})
+		if chosenIndex == 0 {
+			return nil, false, ctx.Err()
+		}
+		if !ok {
+			return nil, false, nil
+		}
+
+		return record.Interface(), true, nil
+	}
+}
+
+// SliceRecordIterator ...
+func SliceRecordIterator(slice interface{}) RecordIterator {
+	sliceValue := reflect.ValueOf(slice)
+	if sliceValue.Kind() != reflect.Slice && sliceValue.Kind() != reflect.Array {
+		panic("unable to make the record iterator: value should be a slice")
+	}
+
+	index := 0
+	return func(ctx context.Context) (interface{}, bool, error) {
+		if index >= sliceValue.Len() {
+			return nil, false, nil
+		}
+
+		record := sliceValue.Index(index).Interface()
+		index++
+
+		return record, true, nil
+	}
+}
+
+// JSONStreamOptions ...
+type JSONStreamOptions struct {
+	Format     JSONStreamFormat
+	Status     int
+	FlushEvery int
+}
+
+// StreamJSONRecords ...
+func StreamJSONRecords(
+	writer http.ResponseWriter,
+	request *http.Request,
+	logger Logger,
+	options JSONStreamOptions,
+	iterator RecordIterator,
+) {
+	contentType := NDJSONContentType
+	if options.Format == JSONArrayStreamFormat {
+		contentType = "application/json"
+	}
+
+	status := options.Status
+	if status == 0 {
+		status = http.StatusOK
+	}
+
+	writer.Header().Set("Content-Type", contentType)
+	writer.WriteHeader(status)
+
+	err := writeJSONRecords(request.Context(), writer, options, iterator)
+	if err != nil {
+		logger.Print(fmt.Sprintf(
+			"%s %s: unable to stream the records: %s",
+			request.Method,
+			request.URL,
+			err,
+		))
+	}
+}
+
+func writeJSONRecords(
+	ctx context.Context,
+	writer http.ResponseWriter,
+	options JSONStreamOptions,
+	iterator RecordIterator,
+) error {
+	flusher, _ := writer.(http.Flusher)
+	flush := func() {
+		if flusher != nil {
+			flusher.Flush()
+		}
+	}
+
+	flushEvery := options.FlushEvery
+	if flushEvery <= 0 {
+		flushEvery = 1
+	}
+
+	isArray := options.Format == JSONArrayStreamFormat
+	if isArray {
+		if _, err := writer.Write([]byte("[")); err != nil {
+			return fmt.Errorf("unable to write the array opening: %w", err)
+		}
+	}
+
+	var recordBuffer bytes.Buffer
+	encoder := json.NewEncoder(&recordBuffer)
+	for index := 0; ; index++ {
+		if err := ctx.Err(); err != nil {
+			return fmt.Errorf("request was cancelled: %w", err)
+		}
+
+		record, ok, err := iterator(ctx)
+		if err != nil {
+			return fmt.Errorf("unable to get the record #%d: %w", index, err)
+		}
+		if !ok {
+			break
+		}
+
+		recordBuffer.Reset()
+		if isArray && index > 0 {
+			recordBuffer.WriteString(",")
+		}
+		if err := encoder.Encode(record); err != nil {
+			return fmt.Errorf("unable to encode the record #%d: %w", index, err)
+		}
+
+		recordBytes := recordBuffer.Bytes()
+		if isArray {
+			recordBytes = bytes.TrimSuffix(recordBytes, []byte("\n"))
+		}
+		if _, err := writer.Write(recordBytes); err != nil {
+			return fmt.Errorf("unable to write the record #%d: %w", index, err)
+		}
+
+		if (index+1)%flushEvery == 0 {
+			flush()
+		}
+	}
+
+	if isArray {
+		if _, err := writer.Write([]byte("]\n")); err != nil {
+			return fmt.Errorf("unable to write the array closing: %w", err)
+		}
+	}
+
+	flush()
+	return nil
+}
+
+// JSONStreamReader ...
+type JSONStreamReader struct {
+	reader    *bufio.Reader
+	decoder   *json.Decoder
+	closer    io.Closer
+	isStarted bool
+	isArray   bool
+}
+
+// NewJSONStreamReader ...
+func NewJSONStreamReader(reader io.Reader) *JSONStreamReader {
+	bufferedReader := bufio.NewReader(reader)
+	closer, _ := reader.(io.Closer)
+
+	return &JSONStreamReader{
+		reader:  bufferedReader,
+		decoder: json.NewDecoder(bufferedReader),
+		closer:  closer,
+	}
+}
+
+// Next ...
+func (reader *JSONStreamReader) Next(data interface{}) error {
+	if !reader.isStarted {
+		if err := reader.start(); err != nil {
+			return err
+		}
+	}
+
+	if reader.isArray {
+		if !reader.decoder.More() {
+			if _, err := reader.decoder.Token(); err != nil {
+				return fmt.Errorf("unable to read the array closing: %w", err)
+			}
+
+			return io.EOF
+		}
+	}
+
+	if err := reader.decoder.Decode(data); err != nil {
+		if errors.Is(err, io.EOF) {
+			return io.EOF
+		}
+
+		return fmt.Errorf("unable to decode the record: %w", err)
+	}
+
+	return nil
+}
+
+// Close ...
+func (reader *JSONStreamReader) Close() error {
+	if reader.closer == nil {
+		return nil
+	}
+
+	return reader.closer.Close()
+}
+
+func (reader *JSONStreamReader) start() error {
+	reader.isStarted = true
+
+	for {
+		symbol, err := reader.reader.ReadByte()
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				return io.EOF
+			}
+
+			return fmt.Errorf("unable to read the stream: %w", err)
+		}
+
+		switch symbol {
+		case ' ', '\t', '\r', '\n':
+			continue
+		}
+
+		if err := reader.reader.UnreadByte(); err != nil {
+			return fmt.Errorf("unable to unread the stream: %w", err)
+		}
+
+		if symbol == '[' {
+			reader.isArray = true
+			if _, err := reader.decoder.Token(); err != nil {
+				return fmt.Errorf("unable to read the array opening: %w", err)
+			}
+		}
+
+		return nil
+	}
+}
+
+// LoadJSONStream ...
+func LoadJSONStream(
+	httpClient HTTPClient,
+	url string,
+	authHeader string,
+) (*JSONStreamReader, error) {
+	return LoadJSONStreamWithContext(
+		context.Background(),
+		httpClient,
+		url,
+		authHeader,
+		CallOptions{},
+	)
+}
+
+// LoadJSONStreamWithContext ...
+//
+// The timeout covers the reading of the stream too,
+// so it ends when the stream reader is closed.
+func LoadJSONStreamWithContext(
+	ctx context.Context,
+	httpClient HTTPClient,
+	url string,
+	authHeader string,
+	options CallOptions,
+) (*JSONStreamReader, error) {
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("unable to create the request: %w", err)
+	}
+
+	request.Header.Add("Accept", NDJSONContentType+", application/json")
+	if authHeader != "" {
+		request.Header.Add("Authorization", authHeader)
+	}
+
+	response, err := DoStreamingRequest(httpClient, request, options)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewJSONStreamReader(response.Body), nil
+}
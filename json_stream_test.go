@@ -0,0 +1,414 @@
+package httputils
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStreamJSONRecords(t *testing.T) {
+	type testData struct {
+		FieldOne int
+	}
+	type args struct {
+		request  *http.Request
+		logger   Logger
+		options  JSONStreamOptions
+		iterator RecordIterator
+	}
+
+	tests := []struct {
+		name         string
+		args         args
+		wantResponse *http.Response
+		wantFlushed  bool
+	}{
+		{
+			name: "success with NDJSON",
+			args: args{
+				request: httptest.NewRequest(http.MethodGet, "http://example.com/test", nil),
+				logger:  &MockLogger{},
+				options: JSONStreamOptions{Format: NDJSONStreamFormat},
+				iterator: SliceRecordIterator([]testData{
+					{FieldOne: 23},
+					{FieldOne: 42},
+				}),
+			},
+			wantResponse: &http.Response{
+				Status: strconv.Itoa(http.StatusOK) + " " +
+					http.StatusText(http.StatusOK),
+				StatusCode: http.StatusOK,
+				Proto:      "HTTP/1.1",
+				ProtoMajor: 1,
+				ProtoMinor: 1,
+				Header:     http.Header{"Content-Type": {NDJSONContentType}},
+				Body: ioutil.NopCloser(bytes.NewReader(
+					[]byte("{\"FieldOne\":23}\n{\"FieldOne\":42}\n"),
+				)),
+				ContentLength: -1,
+			},
+			wantFlushed: true,
+		},
+		{
+			name: "success with a JSON array",
+			args: args{
+				request: httptest.NewRequest(http.MethodGet, "http://example.com/test", nil),
+				logger:  &MockLogger{},
+				options: JSONStreamOptions{
+					Format:     JSONArrayStreamFormat,
+					Status:     http.StatusAccepted,
+					FlushEvery: 10,
+				},
+				iterator: func() RecordIterator {
+					records := make(chan testData, 2)
+					records <- testData{FieldOne: 23}
+					records <- testData{FieldOne: 42}
+					close(records)
+
+					return ChannelRecordIterator(records)
+				}(),
+			},
+			wantResponse: &http.Response{
+				Status: strconv.Itoa(http.StatusAccepted) + " " +
+					http.StatusText(http.StatusAccepted),
+				StatusCode: http.StatusAccepted,
+				Proto:      "HTTP/1.1",
+				ProtoMajor: 1,
+				ProtoMinor: 1,
+				Header:     http.Header{"Content-Type": {"application/json"}},
+				Body: ioutil.NopCloser(bytes.NewReader(
+					[]byte("[{\"FieldOne\":23},{\"FieldOne\":42}]\n"),
+				)),
+				ContentLength: -1,
+			},
+			wantFlushed: true,
+		},
+		{
+			name: "error with the iterator",
+			args: args{
+				request: httptest.NewRequest(http.MethodGet, "http://example.com/test", nil),
+				logger: func() Logger {
+					logger := &MockLogger{}
+					logger.InnerMock.
+						On("Print", []interface{}{
+							"GET http://example.com/test: unable to stream the records: " +
+								"unable to get the record #1: test",
+						}).
+						Return().
+						Times(1)
+
+					return logger
+				}(),
+				options: JSONStreamOptions{Format: JSONArrayStreamFormat},
+				iterator: func() RecordIterator {
+					index := 0
+					return func(ctx context.Context) (interface{}, bool, error) {
+						if index > 0 {
+							return nil, false, errors.New("test")
+						}
+
+						index++
+						return testData{FieldOne: 23}, true, nil
+					}
+				}(),
+			},
+			wantResponse: &http.Response{
+				Status: strconv.Itoa(http.StatusOK) + " " +
+					http.StatusText(http.StatusOK),
+				StatusCode: http.StatusOK,
+				Proto:      "HTTP/1.1",
+				ProtoMajor: 1,
+				ProtoMinor: 1,
+				Header:     http.Header{"Content-Type": {"application/json"}},
+				Body: ioutil.NopCloser(bytes.NewReader(
+					[]byte("[{\"FieldOne\":23}"),
+				)),
+				ContentLength: -1,
+			},
+			wantFlushed: true,
+		},
+		{
+			name: "error with cancellation",
+			args: args{
+				request: func() *http.Request {
+					ctx, cancel := context.WithCancel(context.Background())
+					cancel()
+
+					request :=
+						httptest.NewRequest(http.MethodGet, "http://example.com/test", nil)
+					return request.WithContext(ctx)
+				}(),
+				logger: func() Logger {
+					logger := &MockLogger{}
+					logger.InnerMock.
+						On("Print", []interface{}{
+							"GET http://example.com/test: unable to stream the records: " +
+								"request was cancelled: context canceled",
+						}).
+						Return().
+						Times(1)
+
+					return logger
+				}(),
+				options:  JSONStreamOptions{Format: NDJSONStreamFormat},
+				iterator: ChannelRecordIterator(make(chan testData)),
+			},
+			wantResponse: &http.Response{
+				Status: strconv.Itoa(http.StatusOK) + " " +
+					http.StatusText(http.StatusOK),
+				StatusCode:    http.StatusOK,
+				Proto:         "HTTP/1.1",
+				ProtoMajor:    1,
+				ProtoMinor:    1,
+				Header:        http.Header{"Content-Type": {NDJSONContentType}},
+				Body:          ioutil.NopCloser(bytes.NewReader(nil)),
+				ContentLength: -1,
+			},
+			wantFlushed: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			responseRecorder := httptest.NewRecorder()
+			StreamJSONRecords(
+				responseRecorder,
+				tt.args.request,
+				tt.args.logger,
+				tt.args.options,
+				tt.args.iterator,
+			)
+
+			tt.args.logger.(*MockLogger).InnerMock.AssertExpectations(t)
+			assert.Equal(t, tt.wantResponse, responseRecorder.Result())
+			assert.Equal(t, tt.wantFlushed, responseRecorder.Flushed)
+		})
+	}
+}
+
+func TestJSONStreamReader(t *testing.T) {
+	type testData struct {
+		FieldOne int
+	}
+	type args struct {
+		reader io.Reader
+	}
+
+	tests := []struct {
+		name        string
+		args        args
+		wantRecords []testData
+		wantErr     assert.ErrorAssertionFunc
+	}{
+		{
+			name: "success with NDJSON",
+			args: args{
+				reader: strings.NewReader("{\"FieldOne\":23}\n{\"FieldOne\":42}\n"),
+			},
+			wantRecords: []testData{{FieldOne: 23}, {FieldOne: 42}},
+			wantErr:     assert.NoError,
+		},
+		{
+			name: "success with a JSON array",
+			args: args{
+				reader: strings.NewReader(" \n[{\"FieldOne\":23}, {\"FieldOne\":42}]\n"),
+			},
+			wantRecords: []testData{{FieldOne: 23}, {FieldOne: 42}},
+			wantErr:     assert.NoError,
+		},
+		{
+			name: "success with an empty stream",
+			args: args{
+				reader: strings.NewReader(" \n"),
+			},
+			wantRecords: nil,
+			wantErr:     assert.NoError,
+		},
+		{
+			name: "error with decoding",
+			args: args{
+				reader: strings.NewReader("{\"FieldOne\":23}\nincorrect\n"),
+			},
+			wantRecords: []testData{{FieldOne: 23}},
+			wantErr:     assert.Error,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reader := NewJSONStreamReader(tt.args.reader)
+
+			var records []testData
+			var err error
+			for {
+				var record testData
+				if err = reader.Next(&record); err != nil {
+					break
+				}
+
+				records = append(records, record)
+			}
+			if errors.Is(err, io.EOF) {
+				err = nil
+			}
+
+			assert.Equal(t, tt.wantRecords, records)
+			tt.wantErr(t, err)
+			assert.NoError(t, reader.Close())
+		})
+	}
+}
+
+func TestLoadJSONStream(t *testing.T) {
+	type testData struct {
+		FieldOne int
+	}
+	type args struct {
+		httpClient HTTPClient
+		url        string
+		authHeader string
+	}
+
+	makeRequest := func(authHeader string) *http.Request {
+		request, err := http.NewRequest(http.MethodGet, "http://example.com/", nil)
+		require.NoError(t, err)
+
+		request.Header.Add("Accept", NDJSONContentType+", application/json")
+		if authHeader != "" {
+			request.Header.Add("Authorization", authHeader)
+		}
+
+		return request
+	}
+
+	tests := []struct {
+		name       string
+		args       args
+		wantRecord testData
+		wantErr    assert.ErrorAssertionFunc
+	}{
+		{
+			name: "success",
+			args: args{
+				httpClient: func() HTTPClient {
+					response := &http.Response{
+						StatusCode: http.StatusOK,
+						Body: ioutil.NopCloser(bytes.NewReader(
+							[]byte("{\"FieldOne\":23}\n"),
+						)),
+					}
+
+					httpClient := &MockHTTPClient{}
+					httpClient.InnerMock.
+						On("Do", makeRequest("Bearer token")).
+						Return(response, nil).
+						Times(1)
+
+					return httpClient
+				}(),
+				url:        "http://example.com/",
+				authHeader: "Bearer token",
+			},
+			wantRecord: testData{FieldOne: 23},
+			wantErr:    assert.NoError,
+		},
+		{
+			name: "error with the response status",
+			args: args{
+				httpClient: func() HTTPClient {
+					response := &http.Response{
+						StatusCode: http.StatusInternalServerError,
+						Body:       ioutil.NopCloser(bytes.NewReader([]byte("error"))),
+					}
+
+					httpClient := &MockHTTPClient{}
+					httpClient.InnerMock.
+						On("Do", makeRequest("")).
+						Return(response, nil).
+						Times(1)
+
+					return httpClient
+				}(),
+				url:        "http://example.com/",
+				authHeader: "",
+			},
+			wantRecord: testData{},
+			wantErr: func(t assert.TestingT, err error, msgAndArgs ...interface{}) bool {
+				return assert.EqualError(t, err, "request was failed: 500 error")
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reader, err := LoadJSONStream(
+				tt.args.httpClient,
+				tt.args.url,
+				tt.args.authHeader,
+			)
+
+			var record testData
+			if err == nil {
+				err = reader.Next(&record)
+				reader.Close()
+			}
+
+			tt.args.httpClient.(*MockHTTPClient).InnerMock.AssertExpectations(t)
+			assert.Equal(t, tt.wantRecord, record)
+			tt.wantErr(t, err)
+		})
+	}
+}
+
+func TestLoadJSONStreamWithContext(t *testing.T) {
+	type testData struct {
+		FieldOne int
+	}
+
+	var requestCtx context.Context
+	response := &http.Response{
+		StatusCode: http.StatusOK,
+		Body: ioutil.NopCloser(bytes.NewReader(
+			[]byte("{\"FieldOne\":23}\n"),
+		)),
+	}
+
+	httpClient := &MockHTTPClient{}
+	httpClient.InnerMock.
+		On("Do", mock.AnythingOfType("*http.Request")).
+		Run(func(args mock.Arguments) {
+			requestCtx = args.Get(0).(*http.Request).Context()
+		}).
+		Return(response, nil).
+		Times(1)
+
+	reader, err := LoadJSONStreamWithContext(
+		context.Background(),
+		httpClient,
+		"http://example.com/",
+		"",
+		CallOptions{Timeout: time.Minute},
+	)
+	require.NoError(t, err)
+
+	var record testData
+	err = reader.Next(&record)
+	require.NoError(t, err)
+	require.NoError(t, requestCtx.Err())
+
+	err = reader.Close()
+
+	httpClient.InnerMock.AssertExpectations(t)
+	assert.Equal(t, testData{FieldOne: 23}, record)
+	assert.Equal(t, context.Canceled, requestCtx.Err())
+	assert.NoError(t, err)
+}
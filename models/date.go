@@ -22,6 +22,11 @@ func ParseDate(data string) (Date, error) {
 	return Date(parsedDate), nil
 }
 
+// String ...
+func (date Date) String() string {
+	return time.Time(date).Format(dateFormat)
+}
+
 // UnmarshalJSON ...
 func (date *Date) UnmarshalJSON(data []byte) error {
 	if bytes.Equal(data, []byte("null")) {
@@ -44,6 +49,5 @@ func (date *Date) UnmarshalJSON(data []byte) error {
 
 // MarshalJSON ...
 func (date Date) MarshalJSON() ([]byte, error) {
-	formattedDate := time.Time(date).Format(dateFormat)
-	return json.Marshal(formattedDate)
+	return json.Marshal(date.String())
 }
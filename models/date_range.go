@@ -0,0 +1,178 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// DateRangeSeparator ...
+const DateRangeSeparator = ".."
+
+// ErrInvalidDateRange ...
+var ErrInvalidDateRange = errors.New("invalid date range")
+
+// DateRange ...
+//
+// Nil bounds mean an open-ended range.
+type DateRange struct {
+	From            *Date `json:"from,omitempty"`
+	To              *Date `json:"to,omitempty"`
+	IsFromExclusive bool  `json:"from_exclusive,omitempty"`
+	IsToExclusive   bool  `json:"to_exclusive,omitempty"`
+}
+
+// NewDateRange ...
+func NewDateRange(from Date, to Date) DateRange {
+	return DateRange{From: &from, To: &to}
+}
+
+// ParseDateRange ...
+//
+// It parses a range like "2022-01-01..2022-01-31" with inclusive bounds;
+// either of them can be omitted.
+func ParseDateRange(data string) (DateRange, error) {
+	separatorIndex := strings.Index(data, DateRangeSeparator)
+	if separatorIndex == -1 {
+		return DateRange{}, fmt.Errorf("%w: separator is missed", ErrInvalidDateRange)
+	}
+
+	var dateRange DateRange
+	for _, bound := range []struct {
+		value string
+		date  **Date
+	}{
+		{value: data[:separatorIndex], date: &dateRange.From},
+		{value: data[separatorIndex+len(DateRangeSeparator):], date: &dateRange.To},
+	} {
+		if bound.value == "" {
+			continue
+		}
+
+		date, err := ParseDate(bound.value)
+		if err != nil {
+			return DateRange{}, fmt.Errorf("unable to parse the date: %w", err)
+		}
+
+		*bound.date = &date
+	}
+
+	if err := dateRange.Validate(); err != nil {
+		return DateRange{}, err
+	}
+
+	return dateRange, nil
+}
+
+// String ...
+func (dateRange DateRange) String() string {
+	from, to := dateRange.inclusiveBounds()
+
+	var formattedFrom, formattedTo string
+	if from != nil {
+		formattedFrom = Date(*from).String()
+	}
+	if to != nil {
+		formattedTo = Date(*to).String()
+	}
+
+	return formattedFrom + DateRangeSeparator + formattedTo
+}
+
+// Validate ...
+func (dateRange DateRange) Validate() error {
+	from, to := dateRange.inclusiveBounds()
+	if from != nil && to != nil && from.After(*to) {
+		return fmt.Errorf("%w: it's empty", ErrInvalidDateRange)
+	}
+
+	return nil
+}
+
+// Days ...
+//
+// It returns the number of days in the range
+// or -1 for an open-ended one.
+func (dateRange DateRange) Days() int {
+	from, to := dateRange.inclusiveBounds()
+	if from == nil || to == nil {
+		return -1
+	}
+	if from.After(*to) {
+		return 0
+	}
+
+	return int(to.Sub(*from)/(24*time.Hour)) + 1
+}
+
+// Contains ...
+func (dateRange DateRange) Contains(date Date) bool {
+	from, to := dateRange.inclusiveBounds()
+	dateAsTime := time.Time(date)
+	if from != nil && dateAsTime.Before(*from) {
+		return false
+	}
+	if to != nil && dateAsTime.After(*to) {
+		return false
+	}
+
+	return true
+}
+
+// Overlaps ...
+func (dateRange DateRange) Overlaps(other DateRange) bool {
+	if dateRange.Validate() != nil || other.Validate() != nil {
+		return false
+	}
+
+	from, to := dateRange.inclusiveBounds()
+	otherFrom, otherTo := other.inclusiveBounds()
+	if from != nil && otherTo != nil && from.After(*otherTo) {
+		return false
+	}
+	if otherFrom != nil && to != nil && otherFrom.After(*to) {
+		return false
+	}
+
+	return true
+}
+
+// ForEachDay ...
+//
+// It stops the iteration when the handler returns false.
+func (dateRange DateRange) ForEachDay(handler func(date Date) bool) error {
+	from, to := dateRange.inclusiveBounds()
+	if from == nil || to == nil {
+		return fmt.Errorf("%w: it's open-ended", ErrInvalidDateRange)
+	}
+
+	for day := *from; !day.After(*to); day = day.AddDate(0, 0, 1) {
+		if !handler(Date(day)) {
+			break
+		}
+	}
+
+	return nil
+}
+
+func (dateRange DateRange) inclusiveBounds() (from *time.Time, to *time.Time) {
+	if dateRange.From != nil {
+		fromAsTime := time.Time(*dateRange.From)
+		if dateRange.IsFromExclusive {
+			fromAsTime = fromAsTime.AddDate(0, 0, 1)
+		}
+
+		from = &fromAsTime
+	}
+	if dateRange.To != nil {
+		toAsTime := time.Time(*dateRange.To)
+		if dateRange.IsToExclusive {
+			toAsTime = toAsTime.AddDate(0, 0, -1)
+		}
+
+		to = &toAsTime
+	}
+
+	return from, to
+}
@@ -0,0 +1,322 @@
+package models
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func makeTestDate(day int) *Date {
+	date := Date(time.Date(2022, time.January, day, 0, 0, 0, 0, time.UTC))
+	return &date
+}
+
+func TestParseDateRange(t *testing.T) {
+	type args struct {
+		data string
+	}
+
+	tests := []struct {
+		name    string
+		args    args
+		want    DateRange
+		wantErr assert.ErrorAssertionFunc
+	}{
+		{
+			name:    "success with both bounds",
+			args:    args{data: "2022-01-01..2022-01-31"},
+			want:    DateRange{From: makeTestDate(1), To: makeTestDate(31)},
+			wantErr: assert.NoError,
+		},
+		{
+			name:    "success with the same bounds",
+			args:    args{data: "2022-01-01..2022-01-01"},
+			want:    DateRange{From: makeTestDate(1), To: makeTestDate(1)},
+			wantErr: assert.NoError,
+		},
+		{
+			name:    "success with an open end",
+			args:    args{data: "2022-01-01.."},
+			want:    DateRange{From: makeTestDate(1)},
+			wantErr: assert.NoError,
+		},
+		{
+			name:    "success with an open start",
+			args:    args{data: "..2022-01-31"},
+			want:    DateRange{To: makeTestDate(31)},
+			wantErr: assert.NoError,
+		},
+		{
+			name: "error without a separator",
+			args: args{data: "2022-01-01"},
+			want: DateRange{},
+			wantErr: func(t assert.TestingT, err error, msgAndArgs ...interface{}) bool {
+				return assert.ErrorIs(t, err, ErrInvalidDateRange, msgAndArgs...)
+			},
+		},
+		{
+			name:    "error with an incorrect date",
+			args:    args{data: "2022-01-01..incorrect"},
+			want:    DateRange{},
+			wantErr: assert.Error,
+		},
+		{
+			name: "error with the order",
+			args: args{data: "2022-01-31..2022-01-01"},
+			want: DateRange{},
+			wantErr: func(t assert.TestingT, err error, msgAndArgs ...interface{}) bool {
+				return assert.ErrorIs(t, err, ErrInvalidDateRange, msgAndArgs...)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseDateRange(tt.args.data)
+
+			assert.Equal(t, tt.want, got)
+			tt.wantErr(t, err)
+		})
+	}
+}
+
+func TestDateRange_String(t *testing.T) {
+	tests := []struct {
+		name      string
+		dateRange DateRange
+		want      string
+	}{
+		{
+			name:      "with inclusive bounds",
+			dateRange: NewDateRange(*makeTestDate(1), *makeTestDate(31)),
+			want:      "2022-01-01..2022-01-31",
+		},
+		{
+			name: "with exclusive bounds",
+			dateRange: DateRange{
+				From:            makeTestDate(1),
+				To:              makeTestDate(31),
+				IsFromExclusive: true,
+				IsToExclusive:   true,
+			},
+			want: "2022-01-02..2022-01-30",
+		},
+		{
+			name:      "with an open end",
+			dateRange: DateRange{From: makeTestDate(1)},
+			want:      "2022-01-01..",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.dateRange.String()
+
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestDateRange_JSON(t *testing.T) {
+	dateRange := DateRange{From: makeTestDate(1), IsFromExclusive: true}
+
+	data, err := json.Marshal(dateRange)
+
+	assert.NoError(t, err)
+	assert.Equal(t, `{"from":"2022-01-01","from_exclusive":true}`, string(data))
+
+	var unmarshalledDateRange DateRange
+	err = json.Unmarshal(data, &unmarshalledDateRange)
+
+	assert.NoError(t, err)
+	assert.Equal(t, dateRange, unmarshalledDateRange)
+}
+
+func TestDateRange_Days(t *testing.T) {
+	tests := []struct {
+		name      string
+		dateRange DateRange
+		want      int
+	}{
+		{
+			name:      "with inclusive bounds",
+			dateRange: NewDateRange(*makeTestDate(1), *makeTestDate(31)),
+			want:      31,
+		},
+		{
+			name: "with an exclusive bound",
+			dateRange: DateRange{
+				From:          makeTestDate(1),
+				To:            makeTestDate(2),
+				IsToExclusive: true,
+			},
+			want: 1,
+		},
+		{
+			name: "with an empty range",
+			dateRange: DateRange{
+				From:          makeTestDate(1),
+				To:            makeTestDate(1),
+				IsToExclusive: true,
+			},
+			want: 0,
+		},
+		{
+			name:      "with an open end",
+			dateRange: DateRange{From: makeTestDate(1)},
+			want:      -1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.dateRange.Days()
+
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestDateRange_Contains(t *testing.T) {
+	type args struct {
+		date Date
+	}
+
+	tests := []struct {
+		name      string
+		dateRange DateRange
+		args      args
+		want      bool
+	}{
+		{
+			name:      "with an inner date",
+			dateRange: NewDateRange(*makeTestDate(1), *makeTestDate(31)),
+			args:      args{date: *makeTestDate(15)},
+			want:      true,
+		},
+		{
+			name:      "with an inclusive bound",
+			dateRange: NewDateRange(*makeTestDate(1), *makeTestDate(31)),
+			args:      args{date: *makeTestDate(31)},
+			want:      true,
+		},
+		{
+			name: "with an exclusive bound",
+			dateRange: DateRange{
+				From:            makeTestDate(1),
+				To:              makeTestDate(31),
+				IsFromExclusive: true,
+			},
+			args: args{date: *makeTestDate(1)},
+			want: false,
+		},
+		{
+			name:      "with an open end",
+			dateRange: DateRange{From: makeTestDate(1)},
+			args:      args{date: *makeTestDate(31)},
+			want:      true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.dateRange.Contains(tt.args.date)
+
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestDateRange_Overlaps(t *testing.T) {
+	type args struct {
+		other DateRange
+	}
+
+	tests := []struct {
+		name      string
+		dateRange DateRange
+		args      args
+		want      bool
+	}{
+		{
+			name:      "with an intersection",
+			dateRange: NewDateRange(*makeTestDate(1), *makeTestDate(10)),
+			args:      args{other: NewDateRange(*makeTestDate(10), *makeTestDate(20))},
+			want:      true,
+		},
+		{
+			name:      "without an intersection",
+			dateRange: NewDateRange(*makeTestDate(1), *makeTestDate(10)),
+			args:      args{other: NewDateRange(*makeTestDate(11), *makeTestDate(20))},
+			want:      false,
+		},
+		{
+			name: "with exclusive touching bounds",
+			dateRange: DateRange{
+				From:          makeTestDate(1),
+				To:            makeTestDate(10),
+				IsToExclusive: true,
+			},
+			args: args{other: NewDateRange(*makeTestDate(10), *makeTestDate(20))},
+			want: false,
+		},
+		{
+			name:      "with open ends",
+			dateRange: DateRange{From: makeTestDate(10)},
+			args:      args{other: DateRange{To: makeTestDate(20)}},
+			want:      true,
+		},
+		{
+			name:      "with an empty range",
+			dateRange: NewDateRange(*makeTestDate(10), *makeTestDate(1)),
+			args:      args{other: DateRange{}},
+			want:      false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.dateRange.Overlaps(tt.args.other)
+
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestDateRange_ForEachDay(t *testing.T) {
+	t.Run("with bounds", func(t *testing.T) {
+		dateRange := NewDateRange(
+			*makeTestDate(30),
+			Date(time.Date(2022, time.February, 2, 0, 0, 0, 0, time.UTC)),
+		)
+
+		var days []string
+		err := dateRange.ForEachDay(func(date Date) bool {
+			days = append(days, date.String())
+			return true
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(
+			t,
+			[]string{"2022-01-30", "2022-01-31", "2022-02-01", "2022-02-02"},
+			days,
+		)
+	})
+
+	t.Run("with stopping", func(t *testing.T) {
+		var days []string
+		dateRange := NewDateRange(*makeTestDate(1), *makeTestDate(31))
+		err := dateRange.ForEachDay(func(date Date) bool {
+			days = append(days, date.String())
+			return len(days) < 2
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"2022-01-01", "2022-01-02"}, days)
+	})
+
+	t.Run("with an open end", func(t *testing.T) {
+		dateRange := DateRange{From: makeTestDate(1)}
+		err := dateRange.ForEachDay(func(date Date) bool { return true })
+
+		assert.ErrorIs(t, err, ErrInvalidDateRange)
+	})
+}
@@ -54,6 +54,32 @@ func TestParseDate(t *testing.T) {
 	}
 }
 
+func TestDate_String(t *testing.T) {
+	tests := []struct {
+		name string
+		date Date
+		want string
+	}{
+		{
+			name: "with a non-zero time",
+			date: Date(time.Date(2006, time.January, 2, 0, 0, 0, 0, time.UTC)),
+			want: "2006-01-02",
+		},
+		{
+			name: "with a zero time",
+			date: Date(time.Time{}),
+			want: "0001-01-01",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.date.String()
+
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
 func TestDate_UnmarshalJSON(t *testing.T) {
 	type args struct {
 		data []byte
@@ -0,0 +1,185 @@
+package pagination
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	httputils "github.com/irenicaa/go-http-utils"
+)
+
+// ...
+var (
+	ErrInvalidCursor     = errors.New("invalid cursor")
+	ErrEmptyCursorSecret = errors.New("cursor secret is empty")
+)
+
+// Cursor ...
+type Cursor struct {
+	Position   string `json:"p"`
+	IsBackward bool   `json:"b,omitempty"`
+}
+
+// CursorSigner ...
+type CursorSigner struct {
+	Secret []byte
+}
+
+// Encode ...
+func (signer CursorSigner) Encode(cursor Cursor) (string, error) {
+	if len(signer.Secret) == 0 {
+		return "", ErrEmptyCursorSecret
+	}
+
+	payload, err := json.Marshal(cursor)
+	if err != nil {
+		return "", fmt.Errorf("unable to marshal the cursor: %w", err)
+	}
+
+	encodedPayload := base64.RawURLEncoding.EncodeToString(payload)
+	encodedSignature :=
+		base64.RawURLEncoding.EncodeToString(signer.sign(encodedPayload))
+	return encodedPayload + "." + encodedSignature, nil
+}
+
+// Decode ...
+func (signer CursorSigner) Decode(token string) (Cursor, error) {
+	if len(signer.Secret) == 0 {
+		return Cursor{}, ErrEmptyCursorSecret
+	}
+
+	separatorIndex := strings.Index(token, ".")
+	if separatorIndex == -1 {
+		return Cursor{}, fmt.Errorf("%w: signature is missed", ErrInvalidCursor)
+	}
+
+	encodedPayload, encodedSignature :=
+		token[:separatorIndex], token[separatorIndex+1:]
+	signature, err := base64.RawURLEncoding.DecodeString(encodedSignature)
+	if err != nil {
+		return Cursor{}, fmt.Errorf(
+			"%w: unable to decode the signature: %s",
+			ErrInvalidCursor,
+			err,
+		)
+	}
+	if !hmac.Equal(signature, signer.sign(encodedPayload)) {
+		return Cursor{}, fmt.Errorf("%w: signature is incorrect", ErrInvalidCursor)
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(encodedPayload)
+	if err != nil {
+		return Cursor{}, fmt.Errorf(
+			"%w: unable to decode the payload: %s",
+			ErrInvalidCursor,
+			err,
+		)
+	}
+
+	var cursor Cursor
+	if err := json.Unmarshal(payload, &cursor); err != nil {
+		return Cursor{}, fmt.Errorf(
+			"%w: unable to unmarshal the payload: %s",
+			ErrInvalidCursor,
+			err,
+		)
+	}
+
+	return cursor, nil
+}
+
+func (signer CursorSigner) sign(encodedPayload string) []byte {
+	mac := hmac.New(sha256.New, signer.Secret)
+	mac.Write([]byte(encodedPayload))
+
+	return mac.Sum(nil)
+}
+
+// CursorPage ...
+type CursorPage struct {
+	Limit int
+	// Cursor is nil for the first page.
+	Cursor *Cursor
+}
+
+// ParseCursor ...
+func ParseCursor(
+	request *http.Request,
+	options Options,
+	signer CursorSigner,
+) (CursorPage, error) {
+	var validationErr httputils.ValidationError
+	limit, err := parseLimit(request, options)
+	validationErr.AddError(LimitKey, err)
+
+	var cursor *Cursor
+	if token := request.FormValue(CursorKey); token != "" {
+		decodedCursor, err := signer.Decode(token)
+		if errors.Is(err, ErrEmptyCursorSecret) {
+			return CursorPage{}, err
+		}
+		if err == nil {
+			cursor = &decodedCursor
+		}
+
+		validationErr.AddError(CursorKey, err)
+	}
+
+	if err := validationErr.ErrorOrNil(); err != nil {
+		return CursorPage{}, err
+	}
+
+	return CursorPage{Limit: limit, Cursor: cursor}, nil
+}
+
+// Links ...
+//
+// The next and previous cursors are optional;
+// the corresponding links are omitted for nil ones.
+func (page CursorPage) Links(
+	requestURL *url.URL,
+	signer CursorSigner,
+	next *Cursor,
+	prev *Cursor,
+) (Links, error) {
+	limit := strconv.Itoa(page.Limit)
+	makeLink := func(cursor *Cursor) (string, error) {
+		parameters := map[string]string{LimitKey: limit}
+		if cursor != nil {
+			token, err := signer.Encode(*cursor)
+			if err != nil {
+				return "", err
+			}
+
+			parameters[CursorKey] = token
+		}
+
+		return makePageURL(requestURL, parameters), nil
+	}
+
+	first, err := makeLink(nil)
+	if err != nil {
+		return Links{}, fmt.Errorf("unable to make the first link: %w", err)
+	}
+
+	links := Links{First: first}
+	if next != nil {
+		if links.Next, err = makeLink(next); err != nil {
+			return Links{}, fmt.Errorf("unable to make the next link: %w", err)
+		}
+	}
+	if prev != nil {
+		if links.Prev, err = makeLink(prev); err != nil {
+			return Links{}, fmt.Errorf("unable to make the previous link: %w", err)
+		}
+	}
+
+	return links, nil
+}
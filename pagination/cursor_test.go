@@ -0,0 +1,159 @@
+package pagination
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	httputils "github.com/irenicaa/go-http-utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCursorSigner(t *testing.T) {
+	signer := CursorSigner{Secret: []byte("secret")}
+	token, err := signer.Encode(Cursor{Position: "23", IsBackward: true})
+	require.NoError(t, err)
+
+	cursor, err := signer.Decode(token)
+
+	assert.NoError(t, err)
+	assert.Equal(t, Cursor{Position: "23", IsBackward: true}, cursor)
+
+	for _, incorrectToken := range []string{
+		"",
+		token + "x",
+		"e30." + token[len(token)-43:],
+		"!." + token[len(token)-43:],
+	} {
+		_, err := signer.Decode(incorrectToken)
+
+		assert.ErrorIs(t, err, ErrInvalidCursor, "token %q", incorrectToken)
+	}
+
+	_, err = CursorSigner{Secret: []byte("another")}.Decode(token)
+
+	assert.ErrorIs(t, err, ErrInvalidCursor)
+
+	_, err = CursorSigner{}.Encode(Cursor{Position: "23"})
+
+	assert.ErrorIs(t, err, ErrEmptyCursorSecret)
+
+	_, err = CursorSigner{}.Decode(token)
+
+	assert.ErrorIs(t, err, ErrEmptyCursorSecret)
+}
+
+func TestParseCursor(t *testing.T) {
+	type args struct {
+		request *http.Request
+		options Options
+		signer  CursorSigner
+	}
+
+	signer := CursorSigner{Secret: []byte("secret")}
+	token, err := signer.Encode(Cursor{Position: "23"})
+	require.NoError(t, err)
+
+	tests := []struct {
+		name    string
+		args    args
+		want    CursorPage
+		wantErr assert.ErrorAssertionFunc
+	}{
+		{
+			name: "success with a cursor",
+			args: args{
+				request: httptest.NewRequest(
+					http.MethodGet,
+					"http://example.com/test?limit=5&cursor="+token,
+					nil,
+				),
+				options: Options{},
+				signer:  signer,
+			},
+			want:    CursorPage{Limit: 5, Cursor: &Cursor{Position: "23"}},
+			wantErr: assert.NoError,
+		},
+		{
+			name: "success without a cursor",
+			args: args{
+				request: httptest.NewRequest(http.MethodGet, "http://example.com/test", nil),
+				options: Options{},
+				signer:  signer,
+			},
+			want:    CursorPage{Limit: DefaultLimit, Cursor: nil},
+			wantErr: assert.NoError,
+		},
+		{
+			name: "error with an incorrect cursor",
+			args: args{
+				request: httptest.NewRequest(
+					http.MethodGet,
+					"http://example.com/test?cursor="+token,
+					nil,
+				),
+				options: Options{},
+				signer:  CursorSigner{Secret: []byte("another")},
+			},
+			want: CursorPage{},
+			wantErr: func(t assert.TestingT, err error, msgAndArgs ...interface{}) bool {
+				var validationErr *httputils.ValidationError
+				return assert.True(t, errors.As(err, &validationErr), msgAndArgs...) &&
+					assert.ErrorIs(t, err, ErrInvalidCursor, msgAndArgs...)
+			},
+		},
+		{
+			name: "error with an empty secret",
+			args: args{
+				request: httptest.NewRequest(
+					http.MethodGet,
+					"http://example.com/test?cursor="+token,
+					nil,
+				),
+				options: Options{},
+				signer:  CursorSigner{},
+			},
+			want: CursorPage{},
+			wantErr: func(t assert.TestingT, err error, msgAndArgs ...interface{}) bool {
+				var validationErr *httputils.ValidationError
+				return assert.False(t, errors.As(err, &validationErr), msgAndArgs...) &&
+					assert.ErrorIs(t, err, ErrEmptyCursorSecret, msgAndArgs...)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseCursor(tt.args.request, tt.args.options, tt.args.signer)
+
+			assert.Equal(t, tt.want, got)
+			tt.wantErr(t, err)
+		})
+	}
+}
+
+func TestCursorPage_Links(t *testing.T) {
+	signer := CursorSigner{Secret: []byte("secret")}
+	requestURL, err := url.Parse("http://example.com/test?status=active&cursor=old")
+	require.NoError(t, err)
+
+	page := CursorPage{Limit: 10}
+	links, err := page.Links(requestURL, signer, &Cursor{Position: "42"}, nil)
+	require.NoError(t, err)
+
+	assert.Equal(t, "http://example.com/test?limit=10&status=active", links.First)
+	assert.Empty(t, links.Prev)
+	assert.Empty(t, links.Last)
+
+	nextURL, err := url.Parse(links.Next)
+	require.NoError(t, err)
+
+	cursor, err := signer.Decode(nextURL.Query().Get(CursorKey))
+	require.NoError(t, err)
+
+	assert.Equal(t, Cursor{Position: "42"}, cursor)
+	assert.Equal(t, "10", nextURL.Query().Get(LimitKey))
+	assert.Equal(t, "active", nextURL.Query().Get("status"))
+}
@@ -0,0 +1,49 @@
+package pagination
+
+import (
+	"net/http"
+
+	httputils "github.com/irenicaa/go-http-utils"
+)
+
+// Metadata ...
+type Metadata struct {
+	Limit  int    `json:"limit"`
+	Offset *int   `json:"offset,omitempty"`
+	Total  *int   `json:"total,omitempty"`
+	Links  *Links `json:"links,omitempty"`
+}
+
+// NewLimitOffsetMetadata ...
+//
+// The total can be UnknownTotal; then it's omitted.
+func NewLimitOffsetMetadata(page LimitOffset, links Links, total int) Metadata {
+	offset := page.Offset
+	metadata := Metadata{Limit: page.Limit, Offset: &offset, Links: &links}
+	if total != UnknownTotal {
+		metadata.Total = &total
+	}
+
+	return metadata
+}
+
+// NewCursorMetadata ...
+func NewCursorMetadata(page CursorPage, links Links) Metadata {
+	return Metadata{Limit: page.Limit, Links: &links}
+}
+
+// Envelope ...
+type Envelope struct {
+	Items    interface{} `json:"items"`
+	Metadata Metadata    `json:"paging"`
+}
+
+// HandleEnvelope ...
+func HandleEnvelope(
+	writer http.ResponseWriter,
+	logger httputils.Logger,
+	items interface{},
+	metadata Metadata,
+) {
+	httputils.HandleJSON(writer, logger, Envelope{Items: items, Metadata: metadata})
+}
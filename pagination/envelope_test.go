@@ -0,0 +1,73 @@
+package pagination
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHandleEnvelope(t *testing.T) {
+	type args struct {
+		items    interface{}
+		metadata Metadata
+	}
+
+	tests := []struct {
+		name     string
+		args     args
+		wantBody string
+	}{
+		{
+			name: "with limit and offset",
+			args: args{
+				items: []int{23, 42},
+				metadata: NewLimitOffsetMetadata(
+					LimitOffset{Limit: 2, Offset: 0},
+					Links{Next: "http://example.com/test?limit=2&offset=2"},
+					45,
+				),
+			},
+			wantBody: `{"items":[23,42],"paging":{"limit":2,"offset":0,"total":45,` +
+				`"links":{"next":"http://example.com/test?limit=2\u0026offset=2"}}}`,
+		},
+		{
+			name: "with limit and offset and the unknown total",
+			args: args{
+				items: []int{23, 42},
+				metadata: NewLimitOffsetMetadata(
+					LimitOffset{Limit: 2, Offset: 4},
+					Links{},
+					UnknownTotal,
+				),
+			},
+			wantBody: `{"items":[23,42],"paging":{"limit":2,"offset":4,"links":{}}}`,
+		},
+		{
+			name: "with a cursor",
+			args: args{
+				items: []int{23},
+				metadata: NewCursorMetadata(
+					CursorPage{Limit: 1},
+					Links{First: "http://example.com/test?limit=1"},
+				),
+			},
+			wantBody: `{"items":[23],"paging":{"limit":1,` +
+				`"links":{"first":"http://example.com/test?limit=1"}}}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			responseRecorder := httptest.NewRecorder()
+			HandleEnvelope(
+				responseRecorder,
+				&MockLogger{},
+				tt.args.items,
+				tt.args.metadata,
+			)
+
+			assert.Equal(t, "application/json", responseRecorder.Header().Get("Content-Type"))
+			assert.Equal(t, tt.wantBody, responseRecorder.Body.String())
+		})
+	}
+}
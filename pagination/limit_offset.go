@@ -0,0 +1,151 @@
+package pagination
+
+import (
+	"errors"
+	"math"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	httputils "github.com/irenicaa/go-http-utils"
+)
+
+// ...
+const (
+	LimitKey  = "limit"
+	OffsetKey = "offset"
+	CursorKey = "cursor"
+)
+
+// ...
+const (
+	DefaultLimit    = 20
+	DefaultMaxLimit = 100
+)
+
+// UnknownTotal ...
+const UnknownTotal = -1
+
+// Options ...
+type Options struct {
+	DefaultLimit int
+	MaxLimit     int
+	MaxOffset    int
+}
+
+// LimitOffset ...
+type LimitOffset struct {
+	Limit  int
+	Offset int
+}
+
+// ParseLimitOffset ...
+//
+// A missed limit is replaced by the default one,
+// and a limit above the maximum one is capped.
+func ParseLimitOffset(
+	request *http.Request,
+	options Options,
+) (LimitOffset, error) {
+	var validationErr httputils.ValidationError
+	limit, err := parseLimit(request, options)
+	validationErr.AddError(LimitKey, err)
+
+	maxOffset := options.MaxOffset
+	if maxOffset == 0 {
+		maxOffset = math.MaxInt32
+	}
+
+	offset, err := httputils.GetIntFormValue(request, OffsetKey, 0, maxOffset)
+	if errors.Is(err, httputils.ErrKeyIsMissed) {
+		offset, err = 0, nil
+	}
+	validationErr.AddError(OffsetKey, err)
+
+	if err := validationErr.ErrorOrNil(); err != nil {
+		return LimitOffset{}, err
+	}
+
+	return LimitOffset{Limit: limit, Offset: offset}, nil
+}
+
+// Links ...
+//
+// The total can be UnknownTotal; then the last link is omitted,
+// and the next one is always made.
+// A non-positive limit allows only the first link.
+func (page LimitOffset) Links(requestURL *url.URL, total int) Links {
+	makeLink := func(offset int) string {
+		return makePageURL(requestURL, map[string]string{
+			LimitKey:  strconv.Itoa(page.Limit),
+			OffsetKey: strconv.Itoa(offset),
+		})
+	}
+
+	links := Links{First: makeLink(0)}
+	if page.Limit <= 0 {
+		return links
+	}
+
+	if page.Offset > 0 {
+		prevOffset := page.Offset - page.Limit
+		if prevOffset < 0 {
+			prevOffset = 0
+		}
+
+		links.Prev = makeLink(prevOffset)
+	}
+	if total == UnknownTotal || page.Offset+page.Limit < total {
+		links.Next = makeLink(page.Offset + page.Limit)
+	}
+	if total != UnknownTotal {
+		lastOffset := 0
+		if total > 0 {
+			lastOffset = (total - 1) / page.Limit * page.Limit
+		}
+
+		links.Last = makeLink(lastOffset)
+	}
+
+	return links
+}
+
+func parseLimit(request *http.Request, options Options) (int, error) {
+	defaultLimit := options.DefaultLimit
+	if defaultLimit == 0 {
+		defaultLimit = DefaultLimit
+	}
+
+	maxLimit := options.MaxLimit
+	if maxLimit == 0 {
+		maxLimit = DefaultMaxLimit
+	}
+
+	limit, err := httputils.GetIntFormValue(request, LimitKey, 1, math.MaxInt32)
+	if err != nil {
+		if errors.Is(err, httputils.ErrKeyIsMissed) {
+			limit, err = defaultLimit, nil
+		}
+
+		return limit, err
+	}
+	if limit > maxLimit {
+		limit = maxLimit
+	}
+
+	return limit, nil
+}
+
+func makePageURL(requestURL *url.URL, parameters map[string]string) string {
+	pageURL := *requestURL
+	query := pageURL.Query()
+	for _, key := range []string{LimitKey, OffsetKey, CursorKey} {
+		query.Del(key)
+	}
+	for key, value := range parameters {
+		query.Set(key, value)
+	}
+
+	pageURL.RawQuery = query.Encode()
+	return pageURL.String()
+}
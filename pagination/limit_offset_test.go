@@ -0,0 +1,182 @@
+package pagination
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	httputils "github.com/irenicaa/go-http-utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseLimitOffset(t *testing.T) {
+	type args struct {
+		request *http.Request
+		options Options
+	}
+
+	tests := []struct {
+		name    string
+		args    args
+		want    LimitOffset
+		wantErr assert.ErrorAssertionFunc
+	}{
+		{
+			name: "success with values",
+			args: args{
+				request: httptest.NewRequest(
+					http.MethodGet,
+					"http://example.com/test?limit=23&offset=42",
+					nil,
+				),
+				options: Options{},
+			},
+			want:    LimitOffset{Limit: 23, Offset: 42},
+			wantErr: assert.NoError,
+		},
+		{
+			name: "success with defaults",
+			args: args{
+				request: httptest.NewRequest(http.MethodGet, "http://example.com/test", nil),
+				options: Options{DefaultLimit: 5},
+			},
+			want:    LimitOffset{Limit: 5, Offset: 0},
+			wantErr: assert.NoError,
+		},
+		{
+			name: "success with a capped limit",
+			args: args{
+				request: httptest.NewRequest(
+					http.MethodGet,
+					"http://example.com/test?limit=1000",
+					nil,
+				),
+				options: Options{},
+			},
+			want:    LimitOffset{Limit: DefaultMaxLimit, Offset: 0},
+			wantErr: assert.NoError,
+		},
+		{
+			name: "error with incorrect values",
+			args: args{
+				request: httptest.NewRequest(
+					http.MethodGet,
+					"http://example.com/test?limit=0&offset=100",
+					nil,
+				),
+				options: Options{MaxOffset: 50},
+			},
+			want: LimitOffset{},
+			wantErr: func(t assert.TestingT, err error, msgAndArgs ...interface{}) bool {
+				var validationErr *httputils.ValidationError
+				if !assert.True(t, errors.As(err, &validationErr), msgAndArgs...) {
+					return false
+				}
+
+				return assert.Equal(
+					t,
+					[]httputils.FieldError{
+						{
+							Field:   LimitKey,
+							Code:    httputils.ValidationErrorCodeOutOfRange,
+							Message: "value too less",
+							Err:     httputils.ErrValueTooLess,
+						},
+						{
+							Field:   OffsetKey,
+							Code:    httputils.ValidationErrorCodeOutOfRange,
+							Message: "value too greater",
+							Err:     httputils.ErrValueTooGreater,
+						},
+					},
+					validationErr.Fields,
+					msgAndArgs...,
+				)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseLimitOffset(tt.args.request, tt.args.options)
+
+			assert.Equal(t, tt.want, got)
+			tt.wantErr(t, err)
+		})
+	}
+}
+
+func TestLimitOffset_Links(t *testing.T) {
+	type args struct {
+		requestURL *url.URL
+		total      int
+	}
+
+	requestURL, err := url.Parse("http://example.com/test?status=active&offset=20")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name string
+		page LimitOffset
+		args args
+		want Links
+	}{
+		{
+			name: "with the known total",
+			page: LimitOffset{Limit: 10, Offset: 20},
+			args: args{requestURL: requestURL, total: 45},
+			want: Links{
+				First: "http://example.com/test?limit=10&offset=0&status=active",
+				Prev:  "http://example.com/test?limit=10&offset=10&status=active",
+				Next:  "http://example.com/test?limit=10&offset=30&status=active",
+				Last:  "http://example.com/test?limit=10&offset=40&status=active",
+			},
+		},
+		{
+			name: "with the last page",
+			page: LimitOffset{Limit: 10, Offset: 5},
+			args: args{requestURL: requestURL, total: 15},
+			want: Links{
+				First: "http://example.com/test?limit=10&offset=0&status=active",
+				Prev:  "http://example.com/test?limit=10&offset=0&status=active",
+				Last:  "http://example.com/test?limit=10&offset=10&status=active",
+			},
+		},
+		{
+			name: "with the unknown total",
+			page: LimitOffset{Limit: 10, Offset: 0},
+			args: args{requestURL: requestURL, total: UnknownTotal},
+			want: Links{
+				First: "http://example.com/test?limit=10&offset=0&status=active",
+				Next:  "http://example.com/test?limit=10&offset=10&status=active",
+			},
+		},
+		{
+			name: "with the zero total",
+			page: LimitOffset{Limit: 10, Offset: 0},
+			args: args{requestURL: requestURL, total: 0},
+			want: Links{
+				First: "http://example.com/test?limit=10&offset=0&status=active",
+				Last:  "http://example.com/test?limit=10&offset=0&status=active",
+			},
+		},
+		{
+			name: "with the zero limit",
+			page: LimitOffset{Limit: 0, Offset: 20},
+			args: args{requestURL: requestURL, total: 45},
+			want: Links{
+				First: "http://example.com/test?limit=0&offset=0&status=active",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.page.Links(tt.args.requestURL, tt.args.total)
+
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
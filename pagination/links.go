@@ -0,0 +1,55 @@
+package pagination
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// TotalCountHeader ...
+const TotalCountHeader = "X-Total-Count"
+
+// Links ...
+type Links struct {
+	First string `json:"first,omitempty"`
+	Prev  string `json:"prev,omitempty"`
+	Next  string `json:"next,omitempty"`
+	Last  string `json:"last,omitempty"`
+}
+
+// String ...
+//
+// It formats the links as a value of the Link header (RFC 8288).
+func (links Links) String() string {
+	var values []string
+	for _, link := range []struct {
+		relation string
+		url      string
+	}{
+		{relation: "first", url: links.First},
+		{relation: "prev", url: links.Prev},
+		{relation: "next", url: links.Next},
+		{relation: "last", url: links.Last},
+	} {
+		if link.url == "" {
+			continue
+		}
+
+		values = append(values, fmt.Sprintf("<%s>; rel=%q", link.url, link.relation))
+	}
+
+	return strings.Join(values, ", ")
+}
+
+// WriteHeaders ...
+//
+// The total can be UnknownTotal; then the X-Total-Count header is omitted.
+func WriteHeaders(writer http.ResponseWriter, links Links, total int) {
+	if linkHeader := links.String(); linkHeader != "" {
+		writer.Header().Set("Link", linkHeader)
+	}
+	if total != UnknownTotal {
+		writer.Header().Set(TotalCountHeader, strconv.Itoa(total))
+	}
+}
@@ -0,0 +1,89 @@
+package pagination
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLinks_String(t *testing.T) {
+	tests := []struct {
+		name  string
+		links Links
+		want  string
+	}{
+		{
+			name: "with all the links",
+			links: Links{
+				First: "http://example.com/test?offset=0",
+				Prev:  "http://example.com/test?offset=10",
+				Next:  "http://example.com/test?offset=30",
+				Last:  "http://example.com/test?offset=40",
+			},
+			want: `<http://example.com/test?offset=0>; rel="first", ` +
+				`<http://example.com/test?offset=10>; rel="prev", ` +
+				`<http://example.com/test?offset=30>; rel="next", ` +
+				`<http://example.com/test?offset=40>; rel="last"`,
+		},
+		{
+			name:  "with some links",
+			links: Links{Next: "http://example.com/test?offset=30"},
+			want:  `<http://example.com/test?offset=30>; rel="next"`,
+		},
+		{
+			name:  "without links",
+			links: Links{},
+			want:  "",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.links.String()
+
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestWriteHeaders(t *testing.T) {
+	type args struct {
+		links Links
+		total int
+	}
+
+	tests := []struct {
+		name       string
+		args       args
+		wantHeader http.Header
+	}{
+		{
+			name: "with the known total",
+			args: args{
+				links: Links{Next: "http://example.com/test?offset=30"},
+				total: 45,
+			},
+			wantHeader: http.Header{
+				"Link":          {`<http://example.com/test?offset=30>; rel="next"`},
+				"X-Total-Count": {"45"},
+			},
+		},
+		{
+			name: "with the unknown total and without links",
+			args: args{
+				links: Links{},
+				total: UnknownTotal,
+			},
+			wantHeader: http.Header{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			responseRecorder := httptest.NewRecorder()
+			WriteHeaders(responseRecorder, tt.args.links, tt.args.total)
+
+			assert.Equal(t, tt.wantHeader, responseRecorder.Header())
+		})
+	}
+}
@@ -0,0 +1,158 @@
+package httputils
+
+import (
+	"encoding/json"
+	"fmt"
+	"mime"
+	"net/http"
+)
+
+// ...
+const (
+	ProblemContentType = "application/problem+json"
+	DefaultProblemType = "about:blank"
+)
+
+// Problem ...
+type Problem struct {
+	Type       string
+	Title      string
+	Status     int
+	Detail     string
+	Instance   string
+	Extensions map[string]interface{}
+}
+
+// Error ...
+func (problem Problem) Error() string {
+	message := fmt.Sprintf("problem %d %s", problem.Status, problem.Title)
+	if problem.Detail != "" {
+		message += ": " + problem.Detail
+	}
+
+	return message
+}
+
+// MarshalJSON ...
+func (problem Problem) MarshalJSON() ([]byte, error) {
+	members := make(map[string]interface{}, len(problem.Extensions)+5)
+	for name, value := range problem.Extensions {
+		members[name] = value
+	}
+
+	problemType := problem.Type
+	if problemType == "" {
+		problemType = DefaultProblemType
+	}
+	members["type"] = problemType
+
+	if problem.Title != "" {
+		members["title"] = problem.Title
+	}
+	if problem.Status != 0 {
+		members["status"] = problem.Status
+	}
+	if problem.Detail != "" {
+		members["detail"] = problem.Detail
+	}
+	if problem.Instance != "" {
+		members["instance"] = problem.Instance
+	}
+
+	return json.Marshal(members)
+}
+
+// UnmarshalJSON ...
+func (problem *Problem) UnmarshalJSON(data []byte) error {
+	var members map[string]json.RawMessage
+	if err := json.Unmarshal(data, &members); err != nil {
+		return fmt.Errorf("unable to unmarshal the members: %w", err)
+	}
+
+	var unmarshalledProblem Problem
+	knownMembers := map[string]interface{}{
+		"type":     &unmarshalledProblem.Type,
+		"title":    &unmarshalledProblem.Title,
+		"status":   &unmarshalledProblem.Status,
+		"detail":   &unmarshalledProblem.Detail,
+		"instance": &unmarshalledProblem.Instance,
+	}
+	for name, value := range members {
+		if knownMember, ok := knownMembers[name]; ok {
+			if err := json.Unmarshal(value, knownMember); err != nil {
+				return fmt.Errorf("unable to unmarshal the %s member: %w", name, err)
+			}
+
+			continue
+		}
+
+		var extension interface{}
+		if err := json.Unmarshal(value, &extension); err != nil {
+			return fmt.Errorf("unable to unmarshal the %s extension: %w", name, err)
+		}
+
+		if unmarshalledProblem.Extensions == nil {
+			unmarshalledProblem.Extensions = map[string]interface{}{}
+		}
+		unmarshalledProblem.Extensions[name] = extension
+	}
+	if unmarshalledProblem.Type == "" {
+		unmarshalledProblem.Type = DefaultProblemType
+	}
+
+	*problem = unmarshalledProblem
+	return nil
+}
+
+// HandleProblem ...
+func HandleProblem(
+	writer http.ResponseWriter,
+	logger Logger,
+	problem Problem,
+	format string,
+	arguments ...interface{},
+) {
+	if problem.Status == 0 {
+		problem.Status = http.StatusInternalServerError
+	}
+	if problem.Title == "" {
+		problem.Title = http.StatusText(problem.Status)
+	}
+
+	problemBytes, err := json.Marshal(problem)
+	if err != nil {
+		status, message :=
+			http.StatusInternalServerError, "unable to marshal the problem"
+		ErrorWriter{Logger: logger}.WriteError(writer, nil, status, message, err)
+
+		return
+	}
+
+	message := fmt.Sprintf(format, arguments...)
+	logger.Print(message)
+
+	writer.Header().Set("Content-Type", ProblemContentType)
+	writer.WriteHeader(problem.Status)
+	writer.Write(problemBytes)
+}
+
+func decodeProblem(
+	response *http.Response,
+	responseBytes []byte,
+) (Problem, bool) {
+	mediaType, _, err :=
+		mime.ParseMediaType(response.Header.Get("Content-Type"))
+	if err != nil || mediaType != ProblemContentType {
+		return Problem{}, false
+	}
+
+	var problem Problem
+	if err := json.Unmarshal(responseBytes, &problem); err != nil {
+		return Problem{}, false
+	}
+	if problem.Status == 0 {
+		problem.Status = response.StatusCode
+	}
+
+	return problem, true
+}
@@ -0,0 +1,194 @@
+package httputils
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestProblem_MarshalJSON(t *testing.T) {
+	tests := []struct {
+		name    string
+		problem Problem
+		want    []byte
+		wantErr assert.ErrorAssertionFunc
+	}{
+		{
+			name: "with all members",
+			problem: Problem{
+				Type:       "https://example.com/problems/out-of-credit",
+				Title:      "You do not have enough credit.",
+				Status:     http.StatusForbidden,
+				Detail:     "Your current balance is 30, but that costs 50.",
+				Instance:   "/account/12345/msgs/abc",
+				Extensions: map[string]interface{}{"balance": 30},
+			},
+			want: []byte(`{` +
+				`"balance":30,` +
+				`"detail":"Your current balance is 30, but that costs 50.",` +
+				`"instance":"/account/12345/msgs/abc",` +
+				`"status":403,` +
+				`"title":"You do not have enough credit.",` +
+				`"type":"https://example.com/problems/out-of-credit"` +
+				`}`),
+			wantErr: assert.NoError,
+		},
+		{
+			name:    "with the default type",
+			problem: Problem{Status: http.StatusNotFound},
+			want:    []byte(`{"status":404,"type":"about:blank"}`),
+			wantErr: assert.NoError,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := json.Marshal(tt.problem)
+
+			assert.Equal(t, tt.want, got)
+			tt.wantErr(t, err)
+		})
+	}
+}
+
+func TestProblem_UnmarshalJSON(t *testing.T) {
+	type args struct {
+		data []byte
+	}
+
+	tests := []struct {
+		name        string
+		args        args
+		wantProblem Problem
+		wantErr     assert.ErrorAssertionFunc
+	}{
+		{
+			name: "success with extensions",
+			args: args{
+				data: []byte(`{` +
+					`"type":"https://example.com/problems/out-of-credit",` +
+					`"title":"You do not have enough credit.",` +
+					`"status":403,` +
+					`"balance":30` +
+					`}`),
+			},
+			wantProblem: Problem{
+				Type:       "https://example.com/problems/out-of-credit",
+				Title:      "You do not have enough credit.",
+				Status:     http.StatusForbidden,
+				Extensions: map[string]interface{}{"balance": float64(30)},
+			},
+			wantErr: assert.NoError,
+		},
+		{
+			name: "success with the default type",
+			args: args{
+				data: []byte(`{"status":404}`),
+			},
+			wantProblem: Problem{
+				Type:   DefaultProblemType,
+				Status: http.StatusNotFound,
+			},
+			wantErr: assert.NoError,
+		},
+		{
+			name: "error with an incorrect member",
+			args: args{
+				data: []byte(`{"status":"incorrect"}`),
+			},
+			wantProblem: Problem{},
+			wantErr:     assert.Error,
+		},
+		{
+			name: "error with an incorrect object",
+			args: args{
+				data: []byte("incorrect"),
+			},
+			wantProblem: Problem{},
+			wantErr:     assert.Error,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var problem Problem
+			err := json.Unmarshal(tt.args.data, &problem)
+
+			assert.Equal(t, tt.wantProblem, problem)
+			tt.wantErr(t, err)
+		})
+	}
+}
+
+func TestHandleProblem(t *testing.T) {
+	type args struct {
+		logger    Logger
+		problem   Problem
+		format    string
+		arguments []interface{}
+	}
+
+	tests := []struct {
+		name         string
+		args         args
+		wantResponse *http.Response
+	}{
+		{
+			name: "success",
+			args: args{
+				logger: func() Logger {
+					logger := &MockLogger{}
+					logger.InnerMock.
+						On("Print", []interface{}{"unable to get the todo: sql: no rows"}).
+						Return().
+						Times(1)
+
+					return logger
+				}(),
+				problem: Problem{
+					Status:   http.StatusNotFound,
+					Detail:   "todo was not found",
+					Instance: "/api/v1/todos/23",
+				},
+				format:    "unable to get the todo: %s",
+				arguments: []interface{}{"sql: no rows"},
+			},
+			wantResponse: &http.Response{
+				Status: strconv.Itoa(http.StatusNotFound) + " " +
+					http.StatusText(http.StatusNotFound),
+				StatusCode: http.StatusNotFound,
+				Proto:      "HTTP/1.1",
+				ProtoMajor: 1,
+				ProtoMinor: 1,
+				Header:     http.Header{"Content-Type": {ProblemContentType}},
+				Body: ioutil.NopCloser(bytes.NewReader([]byte(`{` +
+					`"detail":"todo was not found",` +
+					`"instance":"/api/v1/todos/23",` +
+					`"status":404,` +
+					`"title":"Not Found",` +
+					`"type":"about:blank"` +
+					`}`))),
+				ContentLength: -1,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			responseRecorder := httptest.NewRecorder()
+			HandleProblem(
+				responseRecorder,
+				tt.args.logger,
+				tt.args.problem,
+				tt.args.format,
+				tt.args.arguments...,
+			)
+
+			tt.args.logger.(*MockLogger).InnerMock.AssertExpectations(t)
+			assert.Equal(t, tt.wantResponse, responseRecorder.Result())
+		})
+	}
+}
@@ -3,30 +3,77 @@ package httputils
 import (
 	"errors"
 	"fmt"
+	"math"
 	"net/http"
 	"regexp"
+	"sort"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/irenicaa/go-http-utils/models"
 )
 
+// bodies of the value patterns shared by the path patterns,
+// the value validation and the route template parameter types
+const (
+	intRegexp  = `\d+`
+	dateRegexp = `\d{4}-\d{2}-\d{2}`
+	// it matches open-ended ranges too, but not the bare separator
+	dateRangeRegexp = `(?:` + dateRegexp + `\.\.(?:` + dateRegexp + `)?` +
+		`|\.\.` + dateRegexp + `)`
+	uuidRegexp = `[0-9A-Fa-f]{8}-[0-9A-Fa-f]{4}-[0-9A-Fa-f]{4}-[0-9A-Fa-f]{4}` +
+		`-[0-9A-Fa-f]{12}`
+	ulidRegexp = `[0-7][0-9A-HJKMNP-TV-Za-hjkmnp-tv-z]{25}`
+	slugRegexp = `[a-z0-9]+(?:-[a-z0-9]+)*`
+)
+
+// ...
+var (
+	IDPattern        = regexp.MustCompile(`/` + intRegexp)
+	DatePattern      = regexp.MustCompile(`/` + dateRegexp)
+	DateRangePattern = regexp.MustCompile(`/` + dateRangeRegexp)
+	// UUIDPattern and ULIDPattern match whole path segments only.
+	UUIDPattern = regexp.MustCompile(`/` + uuidRegexp + `(?:/|$)`)
+	ULIDPattern = regexp.MustCompile(`/` + ulidRegexp + `(?:/|$)`)
+	// SlugPattern matches the last path segment only.
+	SlugPattern = regexp.MustCompile(`/` + slugRegexp + `/?$`)
+)
+
 // ...
 var (
-	IDPattern   = regexp.MustCompile(`/\d+`)
-	DatePattern = regexp.MustCompile(`/\d{4}-\d{2}-\d{2}`)
+	ErrKeyIsMissed       = errors.New("key is missed")
+	ErrValueTooLess      = errors.New("value too less")
+	ErrValueTooGreater   = errors.New("value too greater")
+	ErrValueIsNotAllowed = errors.New("value is not allowed")
 )
 
-// ErrKeyIsMissed ...
-var ErrKeyIsMissed = errors.New("key is missed")
+var (
+	uuidPattern = regexp.MustCompile(`^` + uuidRegexp + `$`)
+	ulidPattern = regexp.MustCompile(`^` + ulidRegexp + `$`)
+	slugPattern = regexp.MustCompile(`^` + slugRegexp + `$`)
+)
+
+// IDLocation ...
+//
+// A path parameter with the name (by default, "id") is used first,
+// then the path segment at the position if it's set.
+// The position is one-based; a negative one is counted from the end.
+// If neither of them is set, the fallback pattern of the identifier kind
+// is used.
+type IDLocation struct {
+	Name     string
+	Position int
+}
 
 // GetIDFromURL ...
 func GetIDFromURL(request *http.Request) (int, error) {
-	idAsStr := IDPattern.FindString(request.URL.Path)
-	if idAsStr == "" {
+	parameters := findPathParameter(request, "id", IDPattern)
+	if _, ok := parameters["id"]; !ok {
 		return 0, errors.New("unable to find an ID")
 	}
 
-	id, err := strconv.Atoi(idAsStr[1:])
+	id, err := parameters.GetInt("id")
 	if err != nil {
 		return 0, fmt.Errorf("unable to parse the ID: %w", err)
 	}
@@ -36,12 +83,12 @@ func GetIDFromURL(request *http.Request) (int, error) {
 
 // GetDateFromURL ...
 func GetDateFromURL(request *http.Request) (models.Date, error) {
-	dateAsStr := DatePattern.FindString(request.URL.Path)
-	if dateAsStr == "" {
+	parameters := findPathParameter(request, "date", DatePattern)
+	if _, ok := parameters["date"]; !ok {
 		return models.Date{}, errors.New("unable to find a date")
 	}
 
-	date, err := models.ParseDate(dateAsStr[1:])
+	date, err := parameters.GetDate("date")
 	if err != nil {
 		return models.Date{}, fmt.Errorf("unable to parse the date: %w", err)
 	}
@@ -49,6 +96,146 @@ func GetDateFromURL(request *http.Request) (models.Date, error) {
 	return date, nil
 }
 
+// GetDateRangeFromURL ...
+func GetDateRangeFromURL(request *http.Request) (models.DateRange, error) {
+	parameters := findPathParameter(request, "date_range", DateRangePattern)
+	if _, ok := parameters["date_range"]; !ok {
+		return models.DateRange{}, errors.New("unable to find a date range")
+	}
+
+	dateRange, err := parameters.GetDateRange("date_range")
+	if err != nil {
+		return models.DateRange{},
+			fmt.Errorf("unable to parse the date range: %w", err)
+	}
+
+	return dateRange, nil
+}
+
+// GetInt64IDFromURL ...
+func GetInt64IDFromURL(request *http.Request, location IDLocation) (int64, error) {
+	value, ok := locateID(request, location, IDPattern)
+	if !ok {
+		return 0, errors.New("unable to find an ID")
+	}
+
+	id, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("unable to parse the ID: %w", err)
+	}
+
+	return id, nil
+}
+
+// GetUUIDFromURL ...
+//
+// It returns the UUID in the canonical lower-case form.
+func GetUUIDFromURL(request *http.Request, location IDLocation) (string, error) {
+	value, ok := locateID(request, location, UUIDPattern)
+	if !ok {
+		return "", errors.New("unable to find an ID")
+	}
+
+	id, err := parseUUID(value)
+	if err != nil {
+		return "", fmt.Errorf("unable to parse the ID: %w", err)
+	}
+
+	return id, nil
+}
+
+// GetULIDFromURL ...
+//
+// It returns the ULID in the canonical upper-case form.
+func GetULIDFromURL(request *http.Request, location IDLocation) (string, error) {
+	value, ok := locateID(request, location, ULIDPattern)
+	if !ok {
+		return "", errors.New("unable to find an ID")
+	}
+	if !ulidPattern.MatchString(value) {
+		return "", fmt.Errorf(
+			"unable to parse the ID: value is incorrect: %q isn't a ULID",
+			value,
+		)
+	}
+
+	return strings.ToUpper(value), nil
+}
+
+// GetSlugFromURL ...
+func GetSlugFromURL(request *http.Request, location IDLocation) (string, error) {
+	value, ok := locateID(request, location, SlugPattern)
+	if !ok {
+		return "", errors.New("unable to find an ID")
+	}
+	if !slugPattern.MatchString(value) {
+		return "", fmt.Errorf(
+			"unable to parse the ID: value is incorrect: %q isn't a slug",
+			value,
+		)
+	}
+
+	return value, nil
+}
+
+func locateID(
+	request *http.Request,
+	location IDLocation,
+	fallbackPattern *regexp.Regexp,
+) (string, bool) {
+	name := location.Name
+	if name == "" {
+		name = "id"
+	}
+
+	if value, ok := GetPathParameters(request)[name]; ok {
+		return value, value != ""
+	}
+
+	if location.Position != 0 {
+		segments := strings.Split(strings.Trim(request.URL.Path, "/"), "/")
+		index := location.Position - 1
+		if location.Position < 0 {
+			index = len(segments) + location.Position
+		}
+		if index < 0 || index >= len(segments) || segments[index] == "" {
+			return "", false
+		}
+
+		return segments[index], true
+	}
+
+	// an explicitly named parameter shouldn't be confused
+	// with another identifier found by the pattern
+	if location.Name != "" {
+		return "", false
+	}
+
+	value := fallbackPattern.FindString(request.URL.Path)
+	if value == "" {
+		return "", false
+	}
+
+	return strings.TrimSuffix(value[1:], "/"), true
+}
+
+func findPathParameter(
+	request *http.Request,
+	name string,
+	fallbackPattern *regexp.Regexp,
+) PathParameters {
+	if value, ok := GetPathParameters(request)[name]; ok {
+		return PathParameters{name: value}
+	}
+
+	valueAsStr := fallbackPattern.FindString(request.URL.Path)
+	if valueAsStr == "" {
+		return nil
+	}
+
+	return PathParameters{name: valueAsStr[1:]}
+}
+
 // GetIntFormValue ...
 func GetIntFormValue(
 	request *http.Request,
@@ -66,10 +253,10 @@ func GetIntFormValue(
 		return 0, fmt.Errorf("value is incorrect: %w", err)
 	}
 	if valueAsInt < min {
-		return 0, errors.New("value too less")
+		return 0, ErrValueTooLess
 	}
 	if valueAsInt > max {
-		return 0, errors.New("value too greater")
+		return 0, ErrValueTooGreater
 	}
 
 	return valueAsInt, nil
@@ -89,3 +276,608 @@ func GetDateFormValue(request *http.Request, key string) (models.Date, error) {
 
 	return parsedDate, nil
 }
+
+// GetIntFormValueWithDefault ...
+func GetIntFormValueWithDefault(
+	request *http.Request,
+	key string,
+	min int,
+	max int,
+	defaultValue int,
+) (int, error) {
+	value, err := GetIntFormValue(request, key, min, max)
+	if errors.Is(err, ErrKeyIsMissed) {
+		return defaultValue, nil
+	}
+
+	return value, err
+}
+
+// GetDateFormValueWithDefault ...
+func GetDateFormValueWithDefault(
+	request *http.Request,
+	key string,
+	defaultValue models.Date,
+) (models.Date, error) {
+	value, err := GetDateFormValue(request, key)
+	if errors.Is(err, ErrKeyIsMissed) {
+		return defaultValue, nil
+	}
+
+	return value, err
+}
+
+// GetFloatFormValue ...
+func GetFloatFormValue(
+	request *http.Request,
+	key string,
+	min float64,
+	max float64,
+) (float64, error) {
+	value := request.FormValue(key)
+	if value == "" {
+		return 0, ErrKeyIsMissed
+	}
+
+	valueAsFloat, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return 0, fmt.Errorf("value is incorrect: %w", err)
+	}
+	if math.IsNaN(valueAsFloat) || math.IsInf(valueAsFloat, 0) {
+		return 0, errors.New("value is incorrect: it isn't finite")
+	}
+	if valueAsFloat < min {
+		return 0, ErrValueTooLess
+	}
+	if valueAsFloat > max {
+		return 0, ErrValueTooGreater
+	}
+
+	return valueAsFloat, nil
+}
+
+// GetFloatFormValueWithDefault ...
+func GetFloatFormValueWithDefault(
+	request *http.Request,
+	key string,
+	min float64,
+	max float64,
+	defaultValue float64,
+) (float64, error) {
+	value, err := GetFloatFormValue(request, key, min, max)
+	if errors.Is(err, ErrKeyIsMissed) {
+		return defaultValue, nil
+	}
+
+	return value, err
+}
+
+// GetBoolFormValue ...
+//
+// It accepts true/false, 1/0, yes/no and on/off in any case.
+func GetBoolFormValue(request *http.Request, key string) (bool, error) {
+	value := request.FormValue(key)
+	if value == "" {
+		return false, ErrKeyIsMissed
+	}
+
+	switch strings.ToLower(value) {
+	case "true", "1", "yes", "on":
+		return true, nil
+	case "false", "0", "no", "off":
+		return false, nil
+	default:
+		return false, fmt.Errorf("value is incorrect: %q", value)
+	}
+}
+
+// GetBoolFormValueWithDefault ...
+func GetBoolFormValueWithDefault(
+	request *http.Request,
+	key string,
+	defaultValue bool,
+) (bool, error) {
+	value, err := GetBoolFormValue(request, key)
+	if errors.Is(err, ErrKeyIsMissed) {
+		return defaultValue, nil
+	}
+
+	return value, err
+}
+
+// GetDurationFormValue ...
+func GetDurationFormValue(
+	request *http.Request,
+	key string,
+	min time.Duration,
+	max time.Duration,
+) (time.Duration, error) {
+	value := request.FormValue(key)
+	if value == "" {
+		return 0, ErrKeyIsMissed
+	}
+
+	duration, err := time.ParseDuration(value)
+	if err != nil {
+		return 0, fmt.Errorf("unable to parse the duration: %w", err)
+	}
+	if duration < min {
+		return 0, ErrValueTooLess
+	}
+	if duration > max {
+		return 0, ErrValueTooGreater
+	}
+
+	return duration, nil
+}
+
+// GetDurationFormValueWithDefault ...
+func GetDurationFormValueWithDefault(
+	request *http.Request,
+	key string,
+	min time.Duration,
+	max time.Duration,
+	defaultValue time.Duration,
+) (time.Duration, error) {
+	value, err := GetDurationFormValue(request, key, min, max)
+	if errors.Is(err, ErrKeyIsMissed) {
+		return defaultValue, nil
+	}
+
+	return value, err
+}
+
+// GetTimestampFormValue ...
+//
+// It parses a timestamp in the RFC 3339 format.
+func GetTimestampFormValue(request *http.Request, key string) (time.Time, error) {
+	value := request.FormValue(key)
+	if value == "" {
+		return time.Time{}, ErrKeyIsMissed
+	}
+
+	timestamp, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("unable to parse the timestamp: %w", err)
+	}
+
+	return timestamp, nil
+}
+
+// GetTimestampFormValueWithDefault ...
+func GetTimestampFormValueWithDefault(
+	request *http.Request,
+	key string,
+	defaultValue time.Time,
+) (time.Time, error) {
+	value, err := GetTimestampFormValue(request, key)
+	if errors.Is(err, ErrKeyIsMissed) {
+		return defaultValue, nil
+	}
+
+	return value, err
+}
+
+// GetUUIDFormValue ...
+//
+// It returns the UUID in the canonical lower-case form.
+func GetUUIDFormValue(request *http.Request, key string) (string, error) {
+	value := request.FormValue(key)
+	if value == "" {
+		return "", ErrKeyIsMissed
+	}
+
+	return parseUUID(value)
+}
+
+// GetUUIDFormValueWithDefault ...
+func GetUUIDFormValueWithDefault(
+	request *http.Request,
+	key string,
+	defaultValue string,
+) (string, error) {
+	value, err := GetUUIDFormValue(request, key)
+	if errors.Is(err, ErrKeyIsMissed) {
+		return defaultValue, nil
+	}
+
+	return value, err
+}
+
+// GetEnumFormValue ...
+func GetEnumFormValue(
+	request *http.Request,
+	key string,
+	allowedValues []string,
+) (string, error) {
+	value := request.FormValue(key)
+	if value == "" {
+		return "", ErrKeyIsMissed
+	}
+	if !containsString(allowedValues, value) {
+		return "", fmt.Errorf("%q: %w", value, ErrValueIsNotAllowed)
+	}
+
+	return value, nil
+}
+
+// GetEnumFormValueWithDefault ...
+func GetEnumFormValueWithDefault(
+	request *http.Request,
+	key string,
+	allowedValues []string,
+	defaultValue string,
+) (string, error) {
+	value, err := GetEnumFormValue(request, key, allowedValues)
+	if errors.Is(err, ErrKeyIsMissed) {
+		return defaultValue, nil
+	}
+
+	return value, err
+}
+
+func parseUUID(value string) (string, error) {
+	if !uuidPattern.MatchString(value) {
+		return "", fmt.Errorf("value is incorrect: %q isn't a UUID", value)
+	}
+
+	return strings.ToLower(value), nil
+}
+
+// DateRangeOptions ...
+type DateRangeOptions struct {
+	FromKey string
+	ToKey   string
+	// MaxDays limits the number of days in the range; zero means no limit.
+	MaxDays        int
+	IsFromOptional bool
+	IsToOptional   bool
+	IsToExclusive  bool
+}
+
+// GetDateRangeFormValue ...
+//
+// The default keys are "from" and "to".
+// A bound is left open if it's missed and optional;
+// an open-ended range isn't checked against the maximal number of days.
+func GetDateRangeFormValue(
+	request *http.Request,
+	options DateRangeOptions,
+) (models.DateRange, error) {
+	fromKey := options.FromKey
+	if fromKey == "" {
+		fromKey = "from"
+	}
+
+	toKey := options.ToKey
+	if toKey == "" {
+		toKey = "to"
+	}
+
+	var validationErr ValidationError
+	dateRange := models.DateRange{IsToExclusive: options.IsToExclusive}
+	for _, bound := range []struct {
+		key        string
+		isOptional bool
+		date       **models.Date
+	}{
+		{key: fromKey, isOptional: options.IsFromOptional, date: &dateRange.From},
+		{key: toKey, isOptional: options.IsToOptional, date: &dateRange.To},
+	} {
+		date, err := GetDateFormValue(request, bound.key)
+		if err != nil {
+			if !errors.Is(err, ErrKeyIsMissed) || !bound.isOptional {
+				validationErr.AddError(bound.key, err)
+			}
+
+			continue
+		}
+
+		*bound.date = &date
+	}
+	if err := validationErr.ErrorOrNil(); err != nil {
+		return models.DateRange{}, err
+	}
+
+	if err := dateRange.Validate(); err != nil {
+		err = fmt.Errorf("%w: %s", ErrValueTooLess, err)
+		validationErr.AddError(toKey, err)
+	} else if days := dateRange.Days(); options.MaxDays > 0 && days > options.MaxDays {
+		err := fmt.Errorf(
+			"%w: range has %d days instead of %d at most",
+			ErrValueTooGreater,
+			days,
+			options.MaxDays,
+		)
+		validationErr.AddError(toKey, err)
+	}
+	if err := validationErr.ErrorOrNil(); err != nil {
+		return models.DateRange{}, err
+	}
+
+	return dateRange, nil
+}
+
+// GetSortFormValue ...
+//
+// It parses a value like "-created,name",
+// where the minus means the descending order.
+func GetSortFormValue(
+	request *http.Request,
+	key string,
+	allowedFields []string,
+) ([]SortField, error) {
+	if request.Form == nil {
+		request.ParseMultipartForm(defaultMaxMemory)
+	}
+
+	sortFields, err := parseSortFields(request.Form[key], allowedFields)
+	if err != nil {
+		var validationErr ValidationError
+		validationErr.AddError(key, err)
+
+		return nil, validationErr.ErrorOrNil()
+	}
+
+	return sortFields, nil
+}
+
+// GetFilterFormValue ...
+//
+// It parses values like "filter[status]=active"
+// and "filter[created][gte]=2022-01-01"; the default operator is eq.
+// The conditions are ordered by their keys.
+func GetFilterFormValue(
+	request *http.Request,
+	key string,
+	allowedFields map[string]FieldType,
+) ([]FilterCondition, error) {
+	if request.Form == nil {
+		request.ParseMultipartForm(defaultMaxMemory)
+	}
+
+	formKeys := make([]string, 0, len(request.Form))
+	for formKey := range request.Form {
+		formKeys = append(formKeys, formKey)
+	}
+	sort.Strings(formKeys)
+
+	var conditions []FilterCondition
+	var validationErr ValidationError
+	keyPattern := makeFilterKeyPattern(key)
+	for _, formKey := range formKeys {
+		match := keyPattern.FindStringSubmatch(formKey)
+		if match == nil {
+			continue
+		}
+
+		field, operator := match[1], FilterOperator(match[2])
+		if operator == "" {
+			operator = EqualOperator
+		}
+
+		fieldType, ok := allowedFields[field]
+		if !ok {
+			err := fmt.Errorf("%q: %w", field, ErrFieldIsNotAllowed)
+			validationErr.AddError(formKey, err)
+
+			continue
+		}
+
+		for _, value := range request.Form[formKey] {
+			condition, err :=
+				parseFilterCondition(field, operator, value, fieldType)
+			if err != nil {
+				validationErr.AddError(formKey, err)
+				break
+			}
+
+			conditions = append(conditions, condition)
+		}
+	}
+
+	if err := validationErr.ErrorOrNil(); err != nil {
+		return nil, err
+	}
+
+	return conditions, nil
+}
+
+// ListOptions ...
+//
+// Repeated keys are always accepted; with a separator,
+// each of their values is also split into several elements.
+// Zero lengths mean no limits.
+type ListOptions struct {
+	Separator   string
+	MinLength   int
+	MaxLength   int
+	Deduplicate bool
+}
+
+// GetIntListFormValue ...
+func GetIntListFormValue(
+	request *http.Request,
+	key string,
+	min int,
+	max int,
+	options ListOptions,
+) ([]int, error) {
+	elements, err := getListFormValues(request, key, options)
+	if err != nil {
+		return nil, err
+	}
+
+	var valuesAsInts []int
+	usedValues := map[int]struct{}{}
+	for _, element := range elements {
+		valueAsInt, err := strconv.Atoi(element.value)
+		if err != nil {
+			return nil, fmt.Errorf(
+				"element #%d: value is incorrect: %w",
+				element.position,
+				err,
+			)
+		}
+		if valueAsInt < min {
+			return nil,
+				fmt.Errorf("element #%d: %w", element.position, ErrValueTooLess)
+		}
+		if valueAsInt > max {
+			return nil,
+				fmt.Errorf("element #%d: %w", element.position, ErrValueTooGreater)
+		}
+
+		if options.Deduplicate {
+			if _, ok := usedValues[valueAsInt]; ok {
+				continue
+			}
+
+			usedValues[valueAsInt] = struct{}{}
+		}
+
+		valuesAsInts = append(valuesAsInts, valueAsInt)
+	}
+
+	if err := checkListLength(len(valuesAsInts), options); err != nil {
+		return nil, err
+	}
+
+	return valuesAsInts, nil
+}
+
+// GetStringListFormValue ...
+func GetStringListFormValue(
+	request *http.Request,
+	key string,
+	options ListOptions,
+) ([]string, error) {
+	elements, err := getListFormValues(request, key, options)
+	if err != nil {
+		return nil, err
+	}
+
+	var uniqueValues []string
+	usedValues := map[string]struct{}{}
+	for _, element := range elements {
+		if options.Deduplicate {
+			if _, ok := usedValues[element.value]; ok {
+				continue
+			}
+
+			usedValues[element.value] = struct{}{}
+		}
+
+		uniqueValues = append(uniqueValues, element.value)
+	}
+
+	if err := checkListLength(len(uniqueValues), options); err != nil {
+		return nil, err
+	}
+
+	return uniqueValues, nil
+}
+
+// GetDateListFormValue ...
+func GetDateListFormValue(
+	request *http.Request,
+	key string,
+	options ListOptions,
+) ([]models.Date, error) {
+	elements, err := getListFormValues(request, key, options)
+	if err != nil {
+		return nil, err
+	}
+
+	var dates []models.Date
+	usedDates := map[string]struct{}{}
+	for _, element := range elements {
+		date, err := models.ParseDate(element.value)
+		if err != nil {
+			return nil, fmt.Errorf(
+				"element #%d: unable to parse the date: %w",
+				element.position,
+				err,
+			)
+		}
+
+		if options.Deduplicate {
+			if _, ok := usedDates[date.String()]; ok {
+				continue
+			}
+
+			usedDates[date.String()] = struct{}{}
+		}
+
+		dates = append(dates, date)
+	}
+
+	if err := checkListLength(len(dates), options); err != nil {
+		return nil, err
+	}
+
+	return dates, nil
+}
+
+type listElement struct {
+	position int
+	value    string
+}
+
+func getListFormValues(
+	request *http.Request,
+	key string,
+	options ListOptions,
+) ([]listElement, error) {
+	if request.Form == nil {
+		request.ParseMultipartForm(defaultMaxMemory)
+	}
+
+	// empty elements are skipped, but still counted in positions,
+	// so errors point to the elements as they are in the request
+	var elements []listElement
+	var position int
+	for _, value := range request.Form[key] {
+		rawElements := []string{value}
+		if options.Separator != "" {
+			rawElements = strings.Split(value, options.Separator)
+		}
+
+		for _, rawElement := range rawElements {
+			if rawElement = strings.TrimSpace(rawElement); rawElement != "" {
+				elements = append(elements, listElement{
+					position: position,
+					value:    rawElement,
+				})
+			}
+
+			position++
+		}
+	}
+	if len(elements) == 0 {
+		return nil, ErrKeyIsMissed
+	}
+
+	return elements, nil
+}
+
+func checkListLength(length int, options ListOptions) error {
+	if options.MinLength > 0 && length < options.MinLength {
+		return fmt.Errorf(
+			"%w: list has %d elements instead of %d at least",
+			ErrValueTooLess,
+			length,
+			options.MinLength,
+		)
+	}
+	if options.MaxLength > 0 && length > options.MaxLength {
+		return fmt.Errorf(
+			"%w: list has %d elements instead of %d at most",
+			ErrValueTooGreater,
+			length,
+			options.MaxLength,
+		)
+	}
+
+	return nil
+}
@@ -1,6 +1,7 @@
 package httputils
 
 import (
+	"errors"
 	"net/http"
 	"net/http/httptest"
 	"testing"
@@ -126,29 +127,1304 @@ func TestGetDateFromURL(t *testing.T) {
 	}
 }
 
+func TestGetDateRangeFromURL(t *testing.T) {
+	type args struct {
+		request *http.Request
+	}
+
+	tests := []struct {
+		name    string
+		args    args
+		want    models.DateRange
+		wantErr assert.ErrorAssertionFunc
+	}{
+		{
+			name: "success",
+			args: args{
+				request: httptest.NewRequest(
+					http.MethodGet,
+					"http://example.com/api/v1/reports/2006-01-02..2006-01-31",
+					nil,
+				),
+			},
+			want: models.NewDateRange(
+				models.Date(time.Date(2006, time.January, 2, 0, 0, 0, 0, time.UTC)),
+				models.Date(time.Date(2006, time.January, 31, 0, 0, 0, 0, time.UTC)),
+			),
+			wantErr: assert.NoError,
+		},
+		{
+			name: "success with a path parameter",
+			args: args{
+				request: SetPathParameters(
+					httptest.NewRequest(http.MethodGet, "http://example.com/test", nil),
+					PathParameters{"date_range": "2006-01-02..2006-01-31"},
+				),
+			},
+			want: models.NewDateRange(
+				models.Date(time.Date(2006, time.January, 2, 0, 0, 0, 0, time.UTC)),
+				models.Date(time.Date(2006, time.January, 31, 0, 0, 0, 0, time.UTC)),
+			),
+			wantErr: assert.NoError,
+		},
+		{
+			name: "success without the end",
+			args: args{
+				request: httptest.NewRequest(
+					http.MethodGet,
+					"http://example.com/api/v1/reports/2006-01-02..",
+					nil,
+				),
+			},
+			want: models.DateRange{
+				From: func() *models.Date {
+					date := models.Date(
+						time.Date(2006, time.January, 2, 0, 0, 0, 0, time.UTC),
+					)
+					return &date
+				}(),
+			},
+			wantErr: assert.NoError,
+		},
+		{
+			name: "success without the start",
+			args: args{
+				request: httptest.NewRequest(
+					http.MethodGet,
+					"http://example.com/api/v1/reports/..2006-01-31",
+					nil,
+				),
+			},
+			want: models.DateRange{
+				To: func() *models.Date {
+					date := models.Date(
+						time.Date(2006, time.January, 31, 0, 0, 0, 0, time.UTC),
+					)
+					return &date
+				}(),
+			},
+			wantErr: assert.NoError,
+		},
+		{
+			name: "error on finding",
+			args: args{
+				request: httptest.NewRequest(
+					http.MethodGet,
+					"http://example.com/api/v1/reports/2006-01-02",
+					nil,
+				),
+			},
+			want:    models.DateRange{},
+			wantErr: assert.Error,
+		},
+		{
+			name: "error on parsing",
+			args: args{
+				request: httptest.NewRequest(
+					http.MethodGet,
+					"http://example.com/api/v1/reports/2006-01-31..2006-01-02",
+					nil,
+				),
+			},
+			want:    models.DateRange{},
+			wantErr: assert.Error,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := GetDateRangeFromURL(tt.args.request)
+
+			assert.Equal(t, tt.want, got)
+			tt.wantErr(t, err)
+		})
+	}
+}
+
+func TestGetInt64IDFromURL(t *testing.T) {
+	type args struct {
+		request  *http.Request
+		location IDLocation
+	}
+
+	tests := []struct {
+		name    string
+		args    args
+		want    int64
+		wantErr assert.ErrorAssertionFunc
+	}{
+		{
+			name: "success with the fallback pattern",
+			args: args{
+				request: httptest.NewRequest(
+					http.MethodGet,
+					"http://example.com/api/v1/todos/9007199254740993",
+					nil,
+				),
+				location: IDLocation{},
+			},
+			want:    9007199254740993,
+			wantErr: assert.NoError,
+		},
+		{
+			name: "success with a position",
+			args: args{
+				request: httptest.NewRequest(
+					http.MethodGet,
+					"http://example.com/api/v1/users/12/todos/23",
+					nil,
+				),
+				location: IDLocation{Position: 4},
+			},
+			want:    12,
+			wantErr: assert.NoError,
+		},
+		{
+			name: "success with a name",
+			args: args{
+				request: SetPathParameters(
+					httptest.NewRequest(http.MethodGet, "http://example.com/test", nil),
+					PathParameters{"todoID": "23"},
+				),
+				location: IDLocation{Name: "todoID"},
+			},
+			want:    23,
+			wantErr: assert.NoError,
+		},
+		{
+			name: "error on finding",
+			args: args{
+				request: httptest.NewRequest(
+					http.MethodGet,
+					"http://example.com/api/v1/todos",
+					nil,
+				),
+				location: IDLocation{Position: 10},
+			},
+			want: 0,
+			wantErr: func(t assert.TestingT, err error, msgAndArgs ...interface{}) bool {
+				return assert.EqualError(t, err, "unable to find an ID", msgAndArgs...)
+			},
+		},
+		{
+			name: "error on finding with a name",
+			args: args{
+				request: httptest.NewRequest(
+					http.MethodGet,
+					"http://example.com/users/12/posts/5",
+					nil,
+				),
+				location: IDLocation{Name: "postID"},
+			},
+			want: 0,
+			wantErr: func(t assert.TestingT, err error, msgAndArgs ...interface{}) bool {
+				return assert.EqualError(t, err, "unable to find an ID", msgAndArgs...)
+			},
+		},
+		{
+			name: "error on parsing",
+			args: args{
+				request: httptest.NewRequest(
+					http.MethodGet,
+					"http://example.com/api/v1/todos/99999999999999999999",
+					nil,
+				),
+				location: IDLocation{},
+			},
+			want:    0,
+			wantErr: assert.Error,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := GetInt64IDFromURL(tt.args.request, tt.args.location)
+
+			assert.Equal(t, tt.want, got)
+			tt.wantErr(t, err)
+		})
+	}
+}
+
+func TestGetUUIDFromURL(t *testing.T) {
+	type args struct {
+		request  *http.Request
+		location IDLocation
+	}
+
+	tests := []struct {
+		name    string
+		args    args
+		want    string
+		wantErr assert.ErrorAssertionFunc
+	}{
+		{
+			name: "success with the fallback pattern",
+			args: args{
+				request: httptest.NewRequest(
+					http.MethodGet,
+					"http://example.com/api/v1/todos/123E4567-E89B-12D3-A456-426614174000/tags",
+					nil,
+				),
+				location: IDLocation{},
+			},
+			want:    "123e4567-e89b-12d3-a456-426614174000",
+			wantErr: assert.NoError,
+		},
+		{
+			name: "error on finding",
+			args: args{
+				request: httptest.NewRequest(
+					http.MethodGet,
+					"http://example.com/api/v1/todos/23",
+					nil,
+				),
+				location: IDLocation{},
+			},
+			want:    "",
+			wantErr: assert.Error,
+		},
+		{
+			name: "error on finding with a too long segment",
+			args: args{
+				request: httptest.NewRequest(
+					http.MethodGet,
+					"http://example.com/users/123e4567-e89b-12d3-a456-426614174000abcdef/posts",
+					nil,
+				),
+				location: IDLocation{},
+			},
+			want: "",
+			wantErr: func(t assert.TestingT, err error, msgAndArgs ...interface{}) bool {
+				return assert.EqualError(t, err, "unable to find an ID", msgAndArgs...)
+			},
+		},
+		{
+			name: "error on parsing",
+			args: args{
+				request: httptest.NewRequest(
+					http.MethodGet,
+					"http://example.com/api/v1/todos/23",
+					nil,
+				),
+				location: IDLocation{Position: -1},
+			},
+			want: "",
+			wantErr: func(t assert.TestingT, err error, msgAndArgs ...interface{}) bool {
+				return assert.EqualError(
+					t,
+					err,
+					`unable to parse the ID: value is incorrect: "23" isn't a UUID`,
+					msgAndArgs...,
+				)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := GetUUIDFromURL(tt.args.request, tt.args.location)
+
+			assert.Equal(t, tt.want, got)
+			tt.wantErr(t, err)
+		})
+	}
+}
+
+func TestGetULIDFromURL(t *testing.T) {
+	type args struct {
+		request  *http.Request
+		location IDLocation
+	}
+
+	tests := []struct {
+		name    string
+		args    args
+		want    string
+		wantErr assert.ErrorAssertionFunc
+	}{
+		{
+			name: "success with the fallback pattern",
+			args: args{
+				request: httptest.NewRequest(
+					http.MethodGet,
+					"http://example.com/api/v1/events/01arz3ndektsv4rrffq69g5fav",
+					nil,
+				),
+				location: IDLocation{},
+			},
+			want:    "01ARZ3NDEKTSV4RRFFQ69G5FAV",
+			wantErr: assert.NoError,
+		},
+		{
+			name: "error on finding with a too long segment",
+			args: args{
+				request: httptest.NewRequest(
+					http.MethodGet,
+					"http://example.com/api/v1/events/01ARZ3NDEKTSV4RRFFQ69G5FAVABCD",
+					nil,
+				),
+				location: IDLocation{},
+			},
+			want: "",
+			wantErr: func(t assert.TestingT, err error, msgAndArgs ...interface{}) bool {
+				return assert.EqualError(t, err, "unable to find an ID", msgAndArgs...)
+			},
+		},
+		{
+			name: "error on parsing",
+			args: args{
+				request: httptest.NewRequest(
+					http.MethodGet,
+					"http://example.com/api/v1/events/81ARZ3NDEKTSV4RRFFQ69G5FAV",
+					nil,
+				),
+				location: IDLocation{Position: -1},
+			},
+			want:    "",
+			wantErr: assert.Error,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := GetULIDFromURL(tt.args.request, tt.args.location)
+
+			assert.Equal(t, tt.want, got)
+			tt.wantErr(t, err)
+		})
+	}
+}
+
+func TestGetSlugFromURL(t *testing.T) {
+	type args struct {
+		request  *http.Request
+		location IDLocation
+	}
+
+	tests := []struct {
+		name    string
+		args    args
+		want    string
+		wantErr assert.ErrorAssertionFunc
+	}{
+		{
+			name: "success with the fallback pattern",
+			args: args{
+				request: httptest.NewRequest(
+					http.MethodGet,
+					"http://example.com/api/v1/posts/hello-world/",
+					nil,
+				),
+				location: IDLocation{},
+			},
+			want:    "hello-world",
+			wantErr: assert.NoError,
+		},
+		{
+			name: "success with a position",
+			args: args{
+				request: httptest.NewRequest(
+					http.MethodGet,
+					"http://example.com/api/v1/posts/hello-world/comments",
+					nil,
+				),
+				location: IDLocation{Position: -2},
+			},
+			want:    "hello-world",
+			wantErr: assert.NoError,
+		},
+		{
+			name: "error on finding",
+			args: args{
+				request: httptest.NewRequest(
+					http.MethodGet,
+					"http://example.com/api/v1/posts/Hello_World",
+					nil,
+				),
+				location: IDLocation{},
+			},
+			want:    "",
+			wantErr: assert.Error,
+		},
+		{
+			name: "error on parsing",
+			args: args{
+				request: SetPathParameters(
+					httptest.NewRequest(http.MethodGet, "http://example.com/test", nil),
+					PathParameters{"id": "Hello_World"},
+				),
+				location: IDLocation{},
+			},
+			want: "",
+			wantErr: func(t assert.TestingT, err error, msgAndArgs ...interface{}) bool {
+				return assert.EqualError(
+					t,
+					err,
+					`unable to parse the ID: value is incorrect: "Hello_World" isn't a slug`,
+					msgAndArgs...,
+				)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := GetSlugFromURL(tt.args.request, tt.args.location)
+
+			assert.Equal(t, tt.want, got)
+			tt.wantErr(t, err)
+		})
+	}
+}
+
 func TestGetIntFormValue(t *testing.T) {
 	type args struct {
 		request *http.Request
 		key     string
-		min     int
-		max     int
+		min     int
+		max     int
+	}
+
+	tests := []struct {
+		name    string
+		args    args
+		want    int
+		wantErr assert.ErrorAssertionFunc
+	}{
+		{
+			name: "success",
+			args: args{
+				request: httptest.NewRequest(http.MethodGet, "/test?key=23", nil),
+				key:     "key",
+				min:     0,
+				max:     100,
+			},
+			want:    23,
+			wantErr: assert.NoError,
+		},
+		{
+			name: "error with a missed key",
+			args: args{
+				request: httptest.NewRequest(http.MethodGet, "/test", nil),
+				key:     "key",
+				min:     0,
+				max:     100,
+			},
+			want: 0,
+			wantErr: func(t assert.TestingT, err error, msgAndArgs ...interface{}) bool {
+				return assert.Equal(t, ErrKeyIsMissed, err, msgAndArgs...)
+			},
+		},
+		{
+			name: "error with an incorrect key",
+			args: args{
+				request: httptest.NewRequest(http.MethodGet, "/test?key=value", nil),
+				key:     "key",
+				min:     0,
+				max:     100,
+			},
+			want:    0,
+			wantErr: assert.Error,
+		},
+		{
+			name: "error with a too less value",
+			args: args{
+				request: httptest.NewRequest(http.MethodGet, "/test?key=23", nil),
+				key:     "key",
+				min:     50,
+				max:     100,
+			},
+			want: 0,
+			wantErr: func(t assert.TestingT, err error, msgAndArgs ...interface{}) bool {
+				return assert.Equal(t, ErrValueTooLess, err, msgAndArgs...)
+			},
+		},
+		{
+			name: "error with a too greater value",
+			args: args{
+				request: httptest.NewRequest(http.MethodGet, "/test?key=23", nil),
+				key:     "key",
+				min:     0,
+				max:     10,
+			},
+			want: 0,
+			wantErr: func(t assert.TestingT, err error, msgAndArgs ...interface{}) bool {
+				return assert.Equal(t, ErrValueTooGreater, err, msgAndArgs...)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err :=
+				GetIntFormValue(tt.args.request, tt.args.key, tt.args.min, tt.args.max)
+
+			assert.Equal(t, tt.want, got)
+			tt.wantErr(t, err)
+		})
+	}
+}
+
+func TestGetDateFormValue(t *testing.T) {
+	type args struct {
+		request *http.Request
+		key     string
+	}
+
+	tests := []struct {
+		name    string
+		args    args
+		want    models.Date
+		wantErr assert.ErrorAssertionFunc
+	}{
+		{
+			name: "success",
+			args: args{
+				request: httptest.NewRequest(http.MethodGet, "/test?key=2006-01-02", nil),
+				key:     "key",
+			},
+			want:    models.Date(time.Date(2006, time.January, 2, 0, 0, 0, 0, time.UTC)),
+			wantErr: assert.NoError,
+		},
+		{
+			name: "error with a missed key",
+			args: args{
+				request: httptest.NewRequest(http.MethodGet, "/test", nil),
+				key:     "key",
+			},
+			want: models.Date(time.Time{}),
+			wantErr: func(t assert.TestingT, err error, msgAndArgs ...interface{}) bool {
+				return assert.Equal(t, ErrKeyIsMissed, err, msgAndArgs...)
+			},
+		},
+		{
+			name: "error with an incorrect key",
+			args: args{
+				request: httptest.NewRequest(http.MethodGet, "/test?key=value", nil),
+				key:     "key",
+			},
+			want:    models.Date(time.Time{}),
+			wantErr: assert.Error,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := GetDateFormValue(tt.args.request, tt.args.key)
+
+			assert.Equal(t, tt.want, got)
+			tt.wantErr(t, err)
+		})
+	}
+}
+
+func TestGetDateRangeFormValue(t *testing.T) {
+	type args struct {
+		request *http.Request
+		options DateRangeOptions
+	}
+
+	makeDate := func(day int) *models.Date {
+		date := models.Date(time.Date(2022, time.January, day, 0, 0, 0, 0, time.UTC))
+		return &date
+	}
+
+	tests := []struct {
+		name    string
+		args    args
+		want    models.DateRange
+		wantErr assert.ErrorAssertionFunc
+	}{
+		{
+			name: "success",
+			args: args{
+				request: httptest.NewRequest(
+					http.MethodGet,
+					"/test?from=2022-01-01&to=2022-01-31",
+					nil,
+				),
+				options: DateRangeOptions{MaxDays: 31},
+			},
+			want:    models.DateRange{From: makeDate(1), To: makeDate(31)},
+			wantErr: assert.NoError,
+		},
+		{
+			name: "success with custom keys and an exclusive bound",
+			args: args{
+				request: httptest.NewRequest(
+					http.MethodGet,
+					"/test?since=2022-01-01&until=2022-01-02",
+					nil,
+				),
+				options: DateRangeOptions{
+					FromKey:       "since",
+					ToKey:         "until",
+					IsToExclusive: true,
+				},
+			},
+			want: models.DateRange{
+				From:          makeDate(1),
+				To:            makeDate(2),
+				IsToExclusive: true,
+			},
+			wantErr: assert.NoError,
+		},
+		{
+			name: "success with an open-ended range",
+			args: args{
+				request: httptest.NewRequest(http.MethodGet, "/test?from=2022-01-01", nil),
+				options: DateRangeOptions{MaxDays: 31, IsToOptional: true},
+			},
+			want:    models.DateRange{From: makeDate(1)},
+			wantErr: assert.NoError,
+		},
+		{
+			name: "error with missed bounds",
+			args: args{
+				request: httptest.NewRequest(http.MethodGet, "/test?from=incorrect", nil),
+				options: DateRangeOptions{},
+			},
+			want: models.DateRange{},
+			wantErr: func(t assert.TestingT, err error, msgAndArgs ...interface{}) bool {
+				var validationErr *ValidationError
+				if !assert.True(t, errors.As(err, &validationErr), msgAndArgs...) ||
+					!assert.Len(t, validationErr.Fields, 2, msgAndArgs...) {
+					return false
+				}
+
+				return assert.Equal(t, "from", validationErr.Fields[0].Field) &&
+					assert.Equal(t, ValidationErrorCodeBadFormat, validationErr.Fields[0].Code) &&
+					assert.Equal(t, "to", validationErr.Fields[1].Field) &&
+					assert.Equal(t, ValidationErrorCodeMissing, validationErr.Fields[1].Code)
+			},
+		},
+		{
+			name: "error with the order",
+			args: args{
+				request: httptest.NewRequest(
+					http.MethodGet,
+					"/test?from=2022-01-02&to=2022-01-01",
+					nil,
+				),
+				options: DateRangeOptions{},
+			},
+			want: models.DateRange{},
+			wantErr: func(t assert.TestingT, err error, msgAndArgs ...interface{}) bool {
+				return assert.ErrorIs(t, err, ErrValueTooLess, msgAndArgs...)
+			},
+		},
+		{
+			name: "error with the span",
+			args: args{
+				request: httptest.NewRequest(
+					http.MethodGet,
+					"/test?from=2022-01-01&to=2022-01-31",
+					nil,
+				),
+				options: DateRangeOptions{MaxDays: 7},
+			},
+			want: models.DateRange{},
+			wantErr: func(t assert.TestingT, err error, msgAndArgs ...interface{}) bool {
+				return assert.ErrorIs(t, err, ErrValueTooGreater, msgAndArgs...) &&
+					assert.EqualError(
+						t,
+						err,
+						"validation was failed: to: "+
+							"value too greater: range has 31 days instead of 7 at most",
+						msgAndArgs...,
+					)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := GetDateRangeFormValue(tt.args.request, tt.args.options)
+
+			assert.Equal(t, tt.want, got)
+			tt.wantErr(t, err)
+		})
+	}
+}
+
+func TestGetSortFormValue(t *testing.T) {
+	type args struct {
+		request       *http.Request
+		key           string
+		allowedFields []string
+	}
+
+	tests := []struct {
+		name    string
+		args    args
+		want    []SortField
+		wantErr assert.ErrorAssertionFunc
+	}{
+		{
+			name: "success",
+			args: args{
+				request: httptest.NewRequest(
+					http.MethodGet,
+					"/test?sort=-created,name&sort=id",
+					nil,
+				),
+				key:           "sort",
+				allowedFields: []string{"id", "name", "created"},
+			},
+			want: []SortField{
+				{Field: "created", IsDescending: true},
+				{Field: "name", IsDescending: false},
+				{Field: "id", IsDescending: false},
+			},
+			wantErr: assert.NoError,
+		},
+		{
+			name: "success without the key",
+			args: args{
+				request:       httptest.NewRequest(http.MethodGet, "/test", nil),
+				key:           "sort",
+				allowedFields: []string{"id", "name", "created"},
+			},
+			want:    nil,
+			wantErr: assert.NoError,
+		},
+		{
+			name: "error with a disallowed field",
+			args: args{
+				request: httptest.NewRequest(
+					http.MethodGet,
+					"/test?sort=-created,password",
+					nil,
+				),
+				key:           "sort",
+				allowedFields: []string{"id", "name", "created"},
+			},
+			want: nil,
+			wantErr: func(t assert.TestingT, err error, msgAndArgs ...interface{}) bool {
+				var validationErr *ValidationError
+				return assert.True(t, errors.As(err, &validationErr), msgAndArgs...) &&
+					assert.ErrorIs(t, err, ErrFieldIsNotAllowed, msgAndArgs...) &&
+					assert.EqualError(
+						t,
+						err,
+						`validation was failed: sort: "password": field is not allowed`,
+						msgAndArgs...,
+					)
+			},
+		},
+		{
+			name: "error with a duplicated field",
+			args: args{
+				request:       httptest.NewRequest(http.MethodGet, "/test?sort=id,-id", nil),
+				key:           "sort",
+				allowedFields: []string{"id", "name", "created"},
+			},
+			want:    nil,
+			wantErr: assert.Error,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := GetSortFormValue(
+				tt.args.request,
+				tt.args.key,
+				tt.args.allowedFields,
+			)
+
+			assert.Equal(t, tt.want, got)
+			tt.wantErr(t, err)
+		})
+	}
+}
+
+func TestGetFilterFormValue(t *testing.T) {
+	type args struct {
+		request       *http.Request
+		key           string
+		allowedFields map[string]FieldType
+	}
+
+	allowedFields := map[string]FieldType{
+		"id":      IntFieldType,
+		"status":  StringFieldType,
+		"created": DateFieldType,
+	}
+
+	tests := []struct {
+		name    string
+		args    args
+		want    []FilterCondition
+		wantErr assert.ErrorAssertionFunc
+	}{
+		{
+			name: "success",
+			args: args{
+				request: httptest.NewRequest(
+					http.MethodGet,
+					"/test?filter[status]=active"+
+						"&filter[created][gte]=2022-01-01"+
+						"&filter[id][in]=1,2,3"+
+						"&other=value",
+					nil,
+				),
+				key:           "filter",
+				allowedFields: allowedFields,
+			},
+			want: []FilterCondition{
+				{
+					Field:    "created",
+					Operator: GreaterOrEqualOperator,
+					Values: []interface{}{
+						models.Date(time.Date(2022, time.January, 1, 0, 0, 0, 0, time.UTC)),
+					},
+				},
+				{
+					Field:    "id",
+					Operator: InOperator,
+					Values:   []interface{}{1, 2, 3},
+				},
+				{
+					Field:    "status",
+					Operator: EqualOperator,
+					Values:   []interface{}{"active"},
+				},
+			},
+			wantErr: assert.NoError,
+		},
+		{
+			name: "success without conditions",
+			args: args{
+				request:       httptest.NewRequest(http.MethodGet, "/test", nil),
+				key:           "filter",
+				allowedFields: allowedFields,
+			},
+			want:    nil,
+			wantErr: assert.NoError,
+		},
+		{
+			name: "error with incorrect conditions",
+			args: args{
+				request: httptest.NewRequest(
+					http.MethodGet,
+					"/test?filter[password]=secret"+
+						"&filter[status][gt]=active"+
+						"&filter[id][in]=1,two",
+					nil,
+				),
+				key:           "filter",
+				allowedFields: allowedFields,
+			},
+			want: nil,
+			wantErr: func(t assert.TestingT, err error, msgAndArgs ...interface{}) bool {
+				var validationErr *ValidationError
+				if !assert.True(t, errors.As(err, &validationErr), msgAndArgs...) ||
+					!assert.Len(t, validationErr.Fields, 3, msgAndArgs...) {
+					return false
+				}
+
+				return assert.Equal(t, "filter[id][in]", validationErr.Fields[0].Field) &&
+					assert.Equal(t, "filter[password]", validationErr.Fields[1].Field) &&
+					assert.ErrorIs(t, validationErr.Fields[1].Err, ErrFieldIsNotAllowed) &&
+					assert.Equal(t, "filter[status][gt]", validationErr.Fields[2].Field) &&
+					assert.ErrorIs(t, validationErr.Fields[2].Err, ErrOperatorIsNotAllowed)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := GetFilterFormValue(
+				tt.args.request,
+				tt.args.key,
+				tt.args.allowedFields,
+			)
+
+			assert.Equal(t, tt.want, got)
+			tt.wantErr(t, err)
+		})
+	}
+}
+
+func TestGetIntListFormValue(t *testing.T) {
+	type args struct {
+		request *http.Request
+		key     string
+		min     int
+		max     int
+		options ListOptions
+	}
+
+	tests := []struct {
+		name    string
+		args    args
+		want    []int
+		wantErr assert.ErrorAssertionFunc
+	}{
+		{
+			name: "success with repeated keys",
+			args: args{
+				request: httptest.NewRequest(http.MethodGet, "/test?id=1&id=2&id=3", nil),
+				key:     "id",
+				min:     0,
+				max:     100,
+				options: ListOptions{},
+			},
+			want:    []int{1, 2, 3},
+			wantErr: assert.NoError,
+		},
+		{
+			name: "success with a separator and deduplication",
+			args: args{
+				request: httptest.NewRequest(
+					http.MethodGet,
+					"/test?ids=1,2,,3&ids=2,4",
+					nil,
+				),
+				key:     "ids",
+				min:     0,
+				max:     100,
+				options: ListOptions{Separator: ",", Deduplicate: true},
+			},
+			want:    []int{1, 2, 3, 4},
+			wantErr: assert.NoError,
+		},
+		{
+			name: "error with a missed key",
+			args: args{
+				request: httptest.NewRequest(http.MethodGet, "/test?ids=", nil),
+				key:     "ids",
+				min:     0,
+				max:     100,
+				options: ListOptions{Separator: ","},
+			},
+			want: nil,
+			wantErr: func(t assert.TestingT, err error, msgAndArgs ...interface{}) bool {
+				return assert.Equal(t, ErrKeyIsMissed, err, msgAndArgs...)
+			},
+		},
+		{
+			name: "error with an incorrect element",
+			args: args{
+				request: httptest.NewRequest(http.MethodGet, "/test?ids=1,two,3", nil),
+				key:     "ids",
+				min:     0,
+				max:     100,
+				options: ListOptions{Separator: ","},
+			},
+			want: nil,
+			wantErr: func(t assert.TestingT, err error, msgAndArgs ...interface{}) bool {
+				return assert.EqualError(
+					t,
+					err,
+					`element #1: value is incorrect: `+
+						`strconv.Atoi: parsing "two": invalid syntax`,
+					msgAndArgs...,
+				)
+			},
+		},
+		{
+			name: "error with an incorrect element after an empty one",
+			args: args{
+				request: httptest.NewRequest(http.MethodGet, "/test?ids=1,,x", nil),
+				key:     "ids",
+				min:     0,
+				max:     100,
+				options: ListOptions{Separator: ","},
+			},
+			want: nil,
+			wantErr: func(t assert.TestingT, err error, msgAndArgs ...interface{}) bool {
+				return assert.EqualError(
+					t,
+					err,
+					`element #2: value is incorrect: `+
+						`strconv.Atoi: parsing "x": invalid syntax`,
+					msgAndArgs...,
+				)
+			},
+		},
+		{
+			name: "error with an out-of-range element",
+			args: args{
+				request: httptest.NewRequest(http.MethodGet, "/test?ids=1,2,300", nil),
+				key:     "ids",
+				min:     0,
+				max:     100,
+				options: ListOptions{Separator: ","},
+			},
+			want: nil,
+			wantErr: func(t assert.TestingT, err error, msgAndArgs ...interface{}) bool {
+				return assert.ErrorIs(t, err, ErrValueTooGreater, msgAndArgs...) &&
+					assert.EqualError(t, err, "element #2: value too greater", msgAndArgs...)
+			},
+		},
+		{
+			name: "error with a too long list",
+			args: args{
+				request: httptest.NewRequest(http.MethodGet, "/test?ids=1,2,3", nil),
+				key:     "ids",
+				min:     0,
+				max:     100,
+				options: ListOptions{Separator: ",", MaxLength: 2},
+			},
+			want: nil,
+			wantErr: func(t assert.TestingT, err error, msgAndArgs ...interface{}) bool {
+				return assert.ErrorIs(t, err, ErrValueTooGreater, msgAndArgs...)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := GetIntListFormValue(
+				tt.args.request,
+				tt.args.key,
+				tt.args.min,
+				tt.args.max,
+				tt.args.options,
+			)
+
+			assert.Equal(t, tt.want, got)
+			tt.wantErr(t, err)
+		})
+	}
+}
+
+func TestGetStringListFormValue(t *testing.T) {
+	type args struct {
+		request *http.Request
+		key     string
+		options ListOptions
+	}
+
+	tests := []struct {
+		name    string
+		args    args
+		want    []string
+		wantErr assert.ErrorAssertionFunc
+	}{
+		{
+			name: "success",
+			args: args{
+				request: httptest.NewRequest(
+					http.MethodGet,
+					"/test?tag=one&tag=two|three&tag=one",
+					nil,
+				),
+				key:     "tag",
+				options: ListOptions{Separator: "|", Deduplicate: true},
+			},
+			want:    []string{"one", "two", "three"},
+			wantErr: assert.NoError,
+		},
+		{
+			name: "error with a too short list",
+			args: args{
+				request: httptest.NewRequest(http.MethodGet, "/test?tag=one&tag=one", nil),
+				key:     "tag",
+				options: ListOptions{MinLength: 2, Deduplicate: true},
+			},
+			want: nil,
+			wantErr: func(t assert.TestingT, err error, msgAndArgs ...interface{}) bool {
+				return assert.ErrorIs(t, err, ErrValueTooLess, msgAndArgs...) &&
+					assert.EqualError(
+						t,
+						err,
+						"value too less: list has 1 elements instead of 2 at least",
+						msgAndArgs...,
+					)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := GetStringListFormValue(
+				tt.args.request,
+				tt.args.key,
+				tt.args.options,
+			)
+
+			assert.Equal(t, tt.want, got)
+			tt.wantErr(t, err)
+		})
+	}
+}
+
+func TestGetDateListFormValue(t *testing.T) {
+	type args struct {
+		request *http.Request
+		key     string
+		options ListOptions
+	}
+
+	tests := []struct {
+		name    string
+		args    args
+		want    []models.Date
+		wantErr assert.ErrorAssertionFunc
+	}{
+		{
+			name: "success",
+			args: args{
+				request: httptest.NewRequest(
+					http.MethodGet,
+					"/test?days=2006-01-02,2006-01-03,2006-01-02",
+					nil,
+				),
+				key:     "days",
+				options: ListOptions{Separator: ",", Deduplicate: true},
+			},
+			want: []models.Date{
+				models.Date(time.Date(2006, time.January, 2, 0, 0, 0, 0, time.UTC)),
+				models.Date(time.Date(2006, time.January, 3, 0, 0, 0, 0, time.UTC)),
+			},
+			wantErr: assert.NoError,
+		},
+		{
+			name: "error with an incorrect element",
+			args: args{
+				request: httptest.NewRequest(
+					http.MethodGet,
+					"/test?days=2006-01-02&days=incorrect",
+					nil,
+				),
+				key:     "days",
+				options: ListOptions{},
+			},
+			want: nil,
+			wantErr: func(t assert.TestingT, err error, msgAndArgs ...interface{}) bool {
+				return assert.Error(t, err, msgAndArgs...) &&
+					assert.Contains(t, err.Error(), "element #1: ", msgAndArgs...)
+			},
+		},
+		{
+			name: "error with an incorrect element after an empty key",
+			args: args{
+				request: httptest.NewRequest(
+					http.MethodGet,
+					"/test?days=&days=2006-01-02&days=incorrect",
+					nil,
+				),
+				key:     "days",
+				options: ListOptions{},
+			},
+			want: nil,
+			wantErr: func(t assert.TestingT, err error, msgAndArgs ...interface{}) bool {
+				return assert.Error(t, err, msgAndArgs...) &&
+					assert.Contains(t, err.Error(), "element #2: ", msgAndArgs...)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := GetDateListFormValue(
+				tt.args.request,
+				tt.args.key,
+				tt.args.options,
+			)
+
+			assert.Equal(t, tt.want, got)
+			tt.wantErr(t, err)
+		})
+	}
+}
+
+func TestGetIntFormValueWithDefault(t *testing.T) {
+	type args struct {
+		request      *http.Request
+		key          string
+		min          int
+		max          int
+		defaultValue int
+	}
+
+	tests := []struct {
+		name    string
+		args    args
+		want    int
+		wantErr assert.ErrorAssertionFunc
+	}{
+		{
+			name: "success with a value",
+			args: args{
+				request:      httptest.NewRequest(http.MethodGet, "/test?key=23", nil),
+				key:          "key",
+				min:          0,
+				max:          100,
+				defaultValue: 42,
+			},
+			want:    23,
+			wantErr: assert.NoError,
+		},
+		{
+			name: "success with a missed key",
+			args: args{
+				request:      httptest.NewRequest(http.MethodGet, "/test", nil),
+				key:          "key",
+				min:          0,
+				max:          100,
+				defaultValue: 42,
+			},
+			want:    42,
+			wantErr: assert.NoError,
+		},
+		{
+			name: "error with an incorrect value",
+			args: args{
+				request:      httptest.NewRequest(http.MethodGet, "/test?key=123", nil),
+				key:          "key",
+				min:          0,
+				max:          100,
+				defaultValue: 42,
+			},
+			want: 0,
+			wantErr: func(t assert.TestingT, err error, msgAndArgs ...interface{}) bool {
+				return assert.Equal(t, ErrValueTooGreater, err, msgAndArgs...)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := GetIntFormValueWithDefault(
+				tt.args.request,
+				tt.args.key,
+				tt.args.min,
+				tt.args.max,
+				tt.args.defaultValue,
+			)
+
+			assert.Equal(t, tt.want, got)
+			tt.wantErr(t, err)
+		})
+	}
+}
+
+func TestGetDateFormValueWithDefault(t *testing.T) {
+	defaultDate := models.Date(time.Date(2006, time.January, 2, 0, 0, 0, 0, time.UTC))
+
+	got, err := GetDateFormValueWithDefault(
+		httptest.NewRequest(http.MethodGet, "/test", nil),
+		"key",
+		defaultDate,
+	)
+
+	assert.Equal(t, defaultDate, got)
+	assert.NoError(t, err)
+}
+
+func TestGetFloatFormValue(t *testing.T) {
+	type args struct {
+		request *http.Request
+		key     string
+		min     float64
+		max     float64
 	}
 
 	tests := []struct {
 		name    string
 		args    args
-		want    int
+		want    float64
 		wantErr assert.ErrorAssertionFunc
 	}{
 		{
 			name: "success",
 			args: args{
-				request: httptest.NewRequest(http.MethodGet, "/test?key=23", nil),
+				request: httptest.NewRequest(http.MethodGet, "/test?key=2.5", nil),
 				key:     "key",
 				min:     0,
-				max:     100,
+				max:     10,
 			},
-			want:    23,
+			want:    2.5,
 			wantErr: assert.NoError,
 		},
 		{
@@ -157,7 +1433,7 @@ func TestGetIntFormValue(t *testing.T) {
 				request: httptest.NewRequest(http.MethodGet, "/test", nil),
 				key:     "key",
 				min:     0,
-				max:     100,
+				max:     10,
 			},
 			want: 0,
 			wantErr: func(t assert.TestingT, err error, msgAndArgs ...interface{}) bool {
@@ -165,43 +1441,136 @@ func TestGetIntFormValue(t *testing.T) {
 			},
 		},
 		{
-			name: "error with an incorrect key",
+			name: "error with an incorrect value",
 			args: args{
 				request: httptest.NewRequest(http.MethodGet, "/test?key=value", nil),
 				key:     "key",
 				min:     0,
-				max:     100,
+				max:     10,
 			},
 			want:    0,
 			wantErr: assert.Error,
 		},
 		{
-			name: "error with a too less value",
+			name: "error with a non-finite value",
 			args: args{
-				request: httptest.NewRequest(http.MethodGet, "/test?key=23", nil),
+				request: httptest.NewRequest(http.MethodGet, "/test?key=NaN", nil),
 				key:     "key",
-				min:     50,
-				max:     100,
+				min:     0,
+				max:     10,
 			},
 			want:    0,
 			wantErr: assert.Error,
 		},
+		{
+			name: "error with a too less value",
+			args: args{
+				request: httptest.NewRequest(http.MethodGet, "/test?key=-0.5", nil),
+				key:     "key",
+				min:     0,
+				max:     10,
+			},
+			want: 0,
+			wantErr: func(t assert.TestingT, err error, msgAndArgs ...interface{}) bool {
+				return assert.Equal(t, ErrValueTooLess, err, msgAndArgs...)
+			},
+		},
 		{
 			name: "error with a too greater value",
 			args: args{
-				request: httptest.NewRequest(http.MethodGet, "/test?key=23", nil),
+				request: httptest.NewRequest(http.MethodGet, "/test?key=10.5", nil),
 				key:     "key",
 				min:     0,
 				max:     10,
 			},
-			want:    0,
+			want: 0,
+			wantErr: func(t assert.TestingT, err error, msgAndArgs ...interface{}) bool {
+				return assert.Equal(t, ErrValueTooGreater, err, msgAndArgs...)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := GetFloatFormValue(
+				tt.args.request,
+				tt.args.key,
+				tt.args.min,
+				tt.args.max,
+			)
+
+			assert.Equal(t, tt.want, got)
+			tt.wantErr(t, err)
+		})
+	}
+}
+
+func TestGetFloatFormValueWithDefault(t *testing.T) {
+	got, err := GetFloatFormValueWithDefault(
+		httptest.NewRequest(http.MethodGet, "/test", nil),
+		"key",
+		0,
+		10,
+		2.5,
+	)
+
+	assert.Equal(t, 2.5, got)
+	assert.NoError(t, err)
+}
+
+func TestGetBoolFormValue(t *testing.T) {
+	type args struct {
+		request *http.Request
+		key     string
+	}
+
+	tests := []struct {
+		name    string
+		args    args
+		want    bool
+		wantErr assert.ErrorAssertionFunc
+	}{
+		{
+			name: "success with true",
+			args: args{
+				request: httptest.NewRequest(http.MethodGet, "/test?key=Yes", nil),
+				key:     "key",
+			},
+			want:    true,
+			wantErr: assert.NoError,
+		},
+		{
+			name: "success with false",
+			args: args{
+				request: httptest.NewRequest(http.MethodGet, "/test?key=0", nil),
+				key:     "key",
+			},
+			want:    false,
+			wantErr: assert.NoError,
+		},
+		{
+			name: "error with a missed key",
+			args: args{
+				request: httptest.NewRequest(http.MethodGet, "/test", nil),
+				key:     "key",
+			},
+			want: false,
+			wantErr: func(t assert.TestingT, err error, msgAndArgs ...interface{}) bool {
+				return assert.Equal(t, ErrKeyIsMissed, err, msgAndArgs...)
+			},
+		},
+		{
+			name: "error with an incorrect value",
+			args: args{
+				request: httptest.NewRequest(http.MethodGet, "/test?key=maybe", nil),
+				key:     "key",
+			},
+			want:    false,
 			wantErr: assert.Error,
 		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got, err :=
-				GetIntFormValue(tt.args.request, tt.args.key, tt.args.min, tt.args.max)
+			got, err := GetBoolFormValue(tt.args.request, tt.args.key)
 
 			assert.Equal(t, tt.want, got)
 			tt.wantErr(t, err)
@@ -209,25 +1578,40 @@ func TestGetIntFormValue(t *testing.T) {
 	}
 }
 
-func TestGetDateFormValue(t *testing.T) {
+func TestGetBoolFormValueWithDefault(t *testing.T) {
+	got, err := GetBoolFormValueWithDefault(
+		httptest.NewRequest(http.MethodGet, "/test", nil),
+		"key",
+		true,
+	)
+
+	assert.Equal(t, true, got)
+	assert.NoError(t, err)
+}
+
+func TestGetDurationFormValue(t *testing.T) {
 	type args struct {
 		request *http.Request
 		key     string
+		min     time.Duration
+		max     time.Duration
 	}
 
 	tests := []struct {
 		name    string
 		args    args
-		want    models.Date
+		want    time.Duration
 		wantErr assert.ErrorAssertionFunc
 	}{
 		{
 			name: "success",
 			args: args{
-				request: httptest.NewRequest(http.MethodGet, "/test?key=2006-01-02", nil),
+				request: httptest.NewRequest(http.MethodGet, "/test?key=1m30s", nil),
 				key:     "key",
+				min:     time.Second,
+				max:     time.Hour,
 			},
-			want:    models.Date(time.Date(2006, time.January, 2, 0, 0, 0, 0, time.UTC)),
+			want:    90 * time.Second,
 			wantErr: assert.NoError,
 		},
 		{
@@ -235,28 +1619,277 @@ func TestGetDateFormValue(t *testing.T) {
 			args: args{
 				request: httptest.NewRequest(http.MethodGet, "/test", nil),
 				key:     "key",
+				min:     time.Second,
+				max:     time.Hour,
 			},
-			want: models.Date(time.Time{}),
+			want: 0,
 			wantErr: func(t assert.TestingT, err error, msgAndArgs ...interface{}) bool {
 				return assert.Equal(t, ErrKeyIsMissed, err, msgAndArgs...)
 			},
 		},
 		{
-			name: "error with an incorrect key",
+			name: "error with an incorrect value",
 			args: args{
 				request: httptest.NewRequest(http.MethodGet, "/test?key=value", nil),
 				key:     "key",
+				min:     time.Second,
+				max:     time.Hour,
 			},
-			want:    models.Date(time.Time{}),
+			want:    0,
 			wantErr: assert.Error,
 		},
+		{
+			name: "error with a too greater value",
+			args: args{
+				request: httptest.NewRequest(http.MethodGet, "/test?key=2h", nil),
+				key:     "key",
+				min:     time.Second,
+				max:     time.Hour,
+			},
+			want: 0,
+			wantErr: func(t assert.TestingT, err error, msgAndArgs ...interface{}) bool {
+				return assert.Equal(t, ErrValueTooGreater, err, msgAndArgs...)
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got, err := GetDateFormValue(tt.args.request, tt.args.key)
+			got, err := GetDurationFormValue(
+				tt.args.request,
+				tt.args.key,
+				tt.args.min,
+				tt.args.max,
+			)
+
+			assert.Equal(t, tt.want, got)
+			tt.wantErr(t, err)
+		})
+	}
+}
+
+func TestGetDurationFormValueWithDefault(t *testing.T) {
+	got, err := GetDurationFormValueWithDefault(
+		httptest.NewRequest(http.MethodGet, "/test", nil),
+		"key",
+		time.Second,
+		time.Hour,
+		time.Minute,
+	)
+
+	assert.Equal(t, time.Minute, got)
+	assert.NoError(t, err)
+}
+
+func TestGetTimestampFormValue(t *testing.T) {
+	type args struct {
+		request *http.Request
+		key     string
+	}
+
+	tests := []struct {
+		name    string
+		args    args
+		want    time.Time
+		wantErr assert.ErrorAssertionFunc
+	}{
+		{
+			name: "success",
+			args: args{
+				request: httptest.NewRequest(
+					http.MethodGet,
+					"/test?key=2006-01-02T15:04:05Z",
+					nil,
+				),
+				key: "key",
+			},
+			want:    time.Date(2006, time.January, 2, 15, 4, 5, 0, time.UTC),
+			wantErr: assert.NoError,
+		},
+		{
+			name: "error with a missed key",
+			args: args{
+				request: httptest.NewRequest(http.MethodGet, "/test", nil),
+				key:     "key",
+			},
+			want: time.Time{},
+			wantErr: func(t assert.TestingT, err error, msgAndArgs ...interface{}) bool {
+				return assert.Equal(t, ErrKeyIsMissed, err, msgAndArgs...)
+			},
+		},
+		{
+			name: "error with an incorrect value",
+			args: args{
+				request: httptest.NewRequest(http.MethodGet, "/test?key=2006-01-02", nil),
+				key:     "key",
+			},
+			want:    time.Time{},
+			wantErr: assert.Error,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := GetTimestampFormValue(tt.args.request, tt.args.key)
+
+			assert.Equal(t, tt.want, got)
+			tt.wantErr(t, err)
+		})
+	}
+}
+
+func TestGetTimestampFormValueWithDefault(t *testing.T) {
+	defaultTimestamp := time.Date(2006, time.January, 2, 15, 4, 5, 0, time.UTC)
+
+	got, err := GetTimestampFormValueWithDefault(
+		httptest.NewRequest(http.MethodGet, "/test", nil),
+		"key",
+		defaultTimestamp,
+	)
+
+	assert.Equal(t, defaultTimestamp, got)
+	assert.NoError(t, err)
+}
+
+func TestGetUUIDFormValue(t *testing.T) {
+	type args struct {
+		request *http.Request
+		key     string
+	}
+
+	tests := []struct {
+		name    string
+		args    args
+		want    string
+		wantErr assert.ErrorAssertionFunc
+	}{
+		{
+			name: "success",
+			args: args{
+				request: httptest.NewRequest(
+					http.MethodGet,
+					"/test?key=123E4567-E89B-12D3-A456-426614174000",
+					nil,
+				),
+				key: "key",
+			},
+			want:    "123e4567-e89b-12d3-a456-426614174000",
+			wantErr: assert.NoError,
+		},
+		{
+			name: "error with a missed key",
+			args: args{
+				request: httptest.NewRequest(http.MethodGet, "/test", nil),
+				key:     "key",
+			},
+			want: "",
+			wantErr: func(t assert.TestingT, err error, msgAndArgs ...interface{}) bool {
+				return assert.Equal(t, ErrKeyIsMissed, err, msgAndArgs...)
+			},
+		},
+		{
+			name: "error with an incorrect value",
+			args: args{
+				request: httptest.NewRequest(
+					http.MethodGet,
+					"/test?key=123e4567e89b12d3a456426614174000",
+					nil,
+				),
+				key: "key",
+			},
+			want:    "",
+			wantErr: assert.Error,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := GetUUIDFormValue(tt.args.request, tt.args.key)
+
+			assert.Equal(t, tt.want, got)
+			tt.wantErr(t, err)
+		})
+	}
+}
+
+func TestGetUUIDFormValueWithDefault(t *testing.T) {
+	got, err := GetUUIDFormValueWithDefault(
+		httptest.NewRequest(http.MethodGet, "/test", nil),
+		"key",
+		"123e4567-e89b-12d3-a456-426614174000",
+	)
+
+	assert.Equal(t, "123e4567-e89b-12d3-a456-426614174000", got)
+	assert.NoError(t, err)
+}
+
+func TestGetEnumFormValue(t *testing.T) {
+	type args struct {
+		request       *http.Request
+		key           string
+		allowedValues []string
+	}
+
+	tests := []struct {
+		name    string
+		args    args
+		want    string
+		wantErr assert.ErrorAssertionFunc
+	}{
+		{
+			name: "success",
+			args: args{
+				request:       httptest.NewRequest(http.MethodGet, "/test?key=active", nil),
+				key:           "key",
+				allowedValues: []string{"active", "archived"},
+			},
+			want:    "active",
+			wantErr: assert.NoError,
+		},
+		{
+			name: "error with a missed key",
+			args: args{
+				request:       httptest.NewRequest(http.MethodGet, "/test", nil),
+				key:           "key",
+				allowedValues: []string{"active", "archived"},
+			},
+			want: "",
+			wantErr: func(t assert.TestingT, err error, msgAndArgs ...interface{}) bool {
+				return assert.Equal(t, ErrKeyIsMissed, err, msgAndArgs...)
+			},
+		},
+		{
+			name: "error with a disallowed value",
+			args: args{
+				request:       httptest.NewRequest(http.MethodGet, "/test?key=deleted", nil),
+				key:           "key",
+				allowedValues: []string{"active", "archived"},
+			},
+			want: "",
+			wantErr: func(t assert.TestingT, err error, msgAndArgs ...interface{}) bool {
+				return assert.ErrorIs(t, err, ErrValueIsNotAllowed, msgAndArgs...)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := GetEnumFormValue(
+				tt.args.request,
+				tt.args.key,
+				tt.args.allowedValues,
+			)
 
 			assert.Equal(t, tt.want, got)
 			tt.wantErr(t, err)
 		})
 	}
 }
+
+func TestGetEnumFormValueWithDefault(t *testing.T) {
+	got, err := GetEnumFormValueWithDefault(
+		httptest.NewRequest(http.MethodGet, "/test", nil),
+		"key",
+		[]string{"active", "archived"},
+		"active",
+	)
+
+	assert.Equal(t, "active", got)
+	assert.NoError(t, err)
+}
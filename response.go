@@ -1,11 +1,17 @@
 package httputils
 
 import (
+	"bytes"
+	"encoding"
 	"encoding/json"
 	"fmt"
+	"io"
 	"net/http"
+	"reflect"
 )
 
+const prettyJSONIndent = "  "
+
 // Logger ...
 type Logger interface {
 	Print(arguments ...interface{})
@@ -16,8 +22,8 @@ func HandleJSON(writer http.ResponseWriter, logger Logger, data interface{}) {
 	dataBytes, err := json.Marshal(data)
 	if err != nil {
 		status, message :=
-			http.StatusInternalServerError, "unable to marshal the data: %s"
-		HandleError(writer, logger, status, message, err)
+			http.StatusInternalServerError, "unable to marshal the data"
+		ErrorWriter{Logger: logger}.WriteError(writer, nil, status, message, err)
 
 		return
 	}
@@ -26,6 +32,29 @@ func HandleJSON(writer http.ResponseWriter, logger Logger, data interface{}) {
 	writer.Write(dataBytes)
 }
 
+// WriteJSON ...
+func WriteJSON(
+	writer http.ResponseWriter,
+	request *http.Request,
+	logger Logger,
+	status int,
+	data interface{},
+) {
+	var indent string
+	if request != nil {
+		if _, ok := request.URL.Query()["pretty"]; ok {
+			indent = prettyJSONIndent
+		}
+	}
+
+	writer.Header().Set("Content-Type", "application/json")
+	writer.WriteHeader(status)
+
+	if err := encodeJSON(writer, data, indent); err != nil {
+		logger.Print(fmt.Sprintf("unable to encode the data: %s", err))
+	}
+}
+
 // HandleError ...
 func HandleError(
 	writer http.ResponseWriter,
@@ -40,3 +69,85 @@ func HandleError(
 	writer.WriteHeader(status)
 	writer.Write([]byte(message))
 }
+
+func encodeJSON(writer io.Writer, data interface{}, indent string) error {
+	dataValue := reflect.ValueOf(data)
+	if !isStreamableJSONValue(dataValue) {
+		encoder := json.NewEncoder(writer)
+		encoder.SetIndent("", indent)
+
+		return encoder.Encode(data)
+	}
+
+	var separator, elementPrefix, closing []byte
+	if indent == "" {
+		separator, closing = []byte(","), []byte("]\n")
+	} else {
+		separator, closing = []byte(",\n"+indent), []byte("\n]\n")
+		elementPrefix = []byte("\n" + indent)
+	}
+	if dataValue.Len() == 0 {
+		elementPrefix, closing = nil, []byte("]\n")
+	}
+
+	if _, err := writer.Write(append([]byte("["), elementPrefix...)); err != nil {
+		return fmt.Errorf("unable to write the array opening: %w", err)
+	}
+
+	var elementBuffer bytes.Buffer
+	encoder := json.NewEncoder(&elementBuffer)
+	encoder.SetIndent(indent, indent)
+	for index := 0; index < dataValue.Len(); index++ {
+		if index > 0 {
+			if _, err := writer.Write(separator); err != nil {
+				return fmt.Errorf("unable to write the separator: %w", err)
+			}
+		}
+
+		// an addressable element is encoded by the pointer like json.Marshal does,
+		// so pointer receiver marshalers of slice elements are used too
+		element := dataValue.Index(index)
+		if element.CanAddr() {
+			element = element.Addr()
+		}
+
+		elementBuffer.Reset()
+		if err := encoder.Encode(element.Interface()); err != nil {
+			return fmt.Errorf("unable to encode the element #%d: %w", index, err)
+		}
+
+		elementBytes := bytes.TrimSuffix(elementBuffer.Bytes(), []byte("\n"))
+		if _, err := writer.Write(elementBytes); err != nil {
+			return fmt.Errorf("unable to write the element #%d: %w", index, err)
+		}
+	}
+
+	if _, err := writer.Write(closing); err != nil {
+		return fmt.Errorf("unable to write the array closing: %w", err)
+	}
+
+	return nil
+}
+
+func isStreamableJSONValue(dataValue reflect.Value) bool {
+	switch dataValue.Kind() {
+	case reflect.Slice:
+		if dataValue.IsNil() {
+			return false
+		}
+	case reflect.Array:
+	default:
+		return false
+	}
+
+	if dataValue.Type().Elem().Kind() == reflect.Uint8 {
+		return false
+	}
+
+	switch dataValue.Interface().(type) {
+	case json.Marshaler, encoding.TextMarshaler:
+		return false
+	default:
+		return true
+	}
+}
@@ -2,6 +2,7 @@ package httputils
 
 import (
 	"bytes"
+	"encoding/json"
 	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
@@ -9,8 +10,17 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
+type testPointerMarshaler struct {
+	FieldOne int
+}
+
+func (data *testPointerMarshaler) MarshalJSON() ([]byte, error) {
+	return []byte(`"custom"`), nil
+}
+
 func TestHandleJSON(t *testing.T) {
 	type testData struct {
 		FieldOne int
@@ -58,7 +68,7 @@ func TestHandleJSON(t *testing.T) {
 					logger := &MockLogger{}
 					logger.InnerMock.
 						On("Print", []interface{}{
-							"unable to marshal the data: json: unsupported type: func()",
+							"500 unable to marshal the data: json: unsupported type: func()",
 						}).
 						Return().
 						Times(1)
@@ -80,7 +90,7 @@ func TestHandleJSON(t *testing.T) {
 				ProtoMinor: 1,
 				Header:     http.Header{},
 				Body: ioutil.NopCloser(bytes.NewReader(
-					[]byte(`unable to marshal the data: json: unsupported type: func()`),
+					[]byte(`unable to marshal the data`),
 				)),
 				ContentLength: -1,
 			},
@@ -155,3 +165,208 @@ func TestHandleError(t *testing.T) {
 		})
 	}
 }
+
+func TestWriteJSON(t *testing.T) {
+	type testData struct {
+		FieldOne int
+		FieldTwo string
+	}
+	type incorrectTestData struct {
+		FieldOne   int
+		FieldThree func()
+	}
+	type args struct {
+		request *http.Request
+		logger  Logger
+		status  int
+		data    interface{}
+	}
+
+	tests := []struct {
+		name         string
+		args         args
+		wantResponse *http.Response
+	}{
+		{
+			name: "success with an object",
+			args: args{
+				request: httptest.NewRequest(http.MethodPost, "/test", nil),
+				logger:  &MockLogger{},
+				status:  http.StatusCreated,
+				data:    testData{FieldOne: 23, FieldTwo: "test"},
+			},
+			wantResponse: &http.Response{
+				Status: strconv.Itoa(http.StatusCreated) + " " +
+					http.StatusText(http.StatusCreated),
+				StatusCode: http.StatusCreated,
+				Proto:      "HTTP/1.1",
+				ProtoMajor: 1,
+				ProtoMinor: 1,
+				Header:     http.Header{"Content-Type": {"application/json"}},
+				Body: ioutil.NopCloser(bytes.NewReader(
+					[]byte(`{"FieldOne":23,"FieldTwo":"test"}` + "\n"),
+				)),
+				ContentLength: -1,
+			},
+		},
+		{
+			name: "success with a slice",
+			args: args{
+				request: httptest.NewRequest(http.MethodGet, "/test", nil),
+				logger:  &MockLogger{},
+				status:  http.StatusOK,
+				data: []testData{
+					{FieldOne: 23, FieldTwo: "one"},
+					{FieldOne: 42, FieldTwo: "two"},
+				},
+			},
+			wantResponse: &http.Response{
+				Status: strconv.Itoa(http.StatusOK) + " " +
+					http.StatusText(http.StatusOK),
+				StatusCode: http.StatusOK,
+				Proto:      "HTTP/1.1",
+				ProtoMajor: 1,
+				ProtoMinor: 1,
+				Header:     http.Header{"Content-Type": {"application/json"}},
+				Body: ioutil.NopCloser(bytes.NewReader([]byte(
+					`[{"FieldOne":23,"FieldTwo":"one"},` +
+						`{"FieldOne":42,"FieldTwo":"two"}]` + "\n",
+				))),
+				ContentLength: -1,
+			},
+		},
+		{
+			name: "success with a pretty slice",
+			args: args{
+				request: httptest.NewRequest(http.MethodGet, "/test?pretty", nil),
+				logger:  &MockLogger{},
+				status:  http.StatusOK,
+				data: []testData{
+					{FieldOne: 23, FieldTwo: "one"},
+					{FieldOne: 42, FieldTwo: "two"},
+				},
+			},
+			wantResponse: &http.Response{
+				Status: strconv.Itoa(http.StatusOK) + " " +
+					http.StatusText(http.StatusOK),
+				StatusCode: http.StatusOK,
+				Proto:      "HTTP/1.1",
+				ProtoMajor: 1,
+				ProtoMinor: 1,
+				Header:     http.Header{"Content-Type": {"application/json"}},
+				Body: ioutil.NopCloser(bytes.NewReader([]byte(
+					"[\n" +
+						"  {\n" +
+						"    \"FieldOne\": 23,\n" +
+						"    \"FieldTwo\": \"one\"\n" +
+						"  },\n" +
+						"  {\n" +
+						"    \"FieldOne\": 42,\n" +
+						"    \"FieldTwo\": \"two\"\n" +
+						"  }\n" +
+						"]\n",
+				))),
+				ContentLength: -1,
+			},
+		},
+		{
+			name: "success with an empty slice",
+			args: args{
+				request: httptest.NewRequest(http.MethodGet, "/test?pretty", nil),
+				logger:  &MockLogger{},
+				status:  http.StatusOK,
+				data:    []testData{},
+			},
+			wantResponse: &http.Response{
+				Status: strconv.Itoa(http.StatusOK) + " " +
+					http.StatusText(http.StatusOK),
+				StatusCode:    http.StatusOK,
+				Proto:         "HTTP/1.1",
+				ProtoMajor:    1,
+				ProtoMinor:    1,
+				Header:        http.Header{"Content-Type": {"application/json"}},
+				Body:          ioutil.NopCloser(bytes.NewReader([]byte("[]\n"))),
+				ContentLength: -1,
+			},
+		},
+		{
+			name: "error with an element",
+			args: args{
+				request: httptest.NewRequest(http.MethodGet, "/test", nil),
+				logger: func() Logger {
+					logger := &MockLogger{}
+					logger.InnerMock.
+						On("Print", []interface{}{
+							"unable to encode the data: unable to encode the element #1: " +
+								"json: unsupported type: func()",
+						}).
+						Return().
+						Times(1)
+
+					return logger
+				}(),
+				status: http.StatusOK,
+				data: []interface{}{
+					testData{FieldOne: 23, FieldTwo: "one"},
+					incorrectTestData{FieldOne: 42, FieldThree: func() {}},
+				},
+			},
+			wantResponse: &http.Response{
+				Status: strconv.Itoa(http.StatusOK) + " " +
+					http.StatusText(http.StatusOK),
+				StatusCode: http.StatusOK,
+				Proto:      "HTTP/1.1",
+				ProtoMajor: 1,
+				ProtoMinor: 1,
+				Header:     http.Header{"Content-Type": {"application/json"}},
+				Body: ioutil.NopCloser(bytes.NewReader(
+					[]byte(`[{"FieldOne":23,"FieldTwo":"one"},`),
+				)),
+				ContentLength: -1,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			responseRecorder := httptest.NewRecorder()
+			WriteJSON(
+				responseRecorder,
+				tt.args.request,
+				tt.args.logger,
+				tt.args.status,
+				tt.args.data,
+			)
+
+			tt.args.logger.(*MockLogger).InnerMock.AssertExpectations(t)
+			assert.Equal(t, tt.wantResponse, responseRecorder.Result())
+		})
+	}
+}
+
+func Test_encodeJSON(t *testing.T) {
+	tests := []struct {
+		name string
+		data interface{}
+	}{
+		{
+			name: "with a slice of pointer receiver marshalers",
+			data: []testPointerMarshaler{{FieldOne: 23}, {FieldOne: 42}},
+		},
+		{
+			name: "with an array of pointer receiver marshalers",
+			data: [2]testPointerMarshaler{{FieldOne: 23}, {FieldOne: 42}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			wantBytes, err := json.Marshal(tt.data)
+			require.NoError(t, err)
+
+			var buffer bytes.Buffer
+			err = encodeJSON(&buffer, tt.data, "")
+
+			assert.Equal(t, string(wantBytes)+"\n", buffer.String())
+			assert.NoError(t, err)
+		})
+	}
+}
@@ -0,0 +1,241 @@
+package httputils
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/irenicaa/go-http-utils/models"
+)
+
+type pathParametersKey struct{}
+
+var (
+	parameterNamePattern  = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+	parameterTypePatterns = map[string]string{
+		"":          `[^/]+`,
+		"int":       intRegexp,
+		"date":      dateRegexp,
+		"daterange": dateRangeRegexp,
+		"uuid":      uuidRegexp,
+		"ulid":      ulidRegexp,
+		"slug":      slugRegexp,
+	}
+)
+
+// ErrParameterIsMissed ...
+var ErrParameterIsMissed = errors.New("parameter is missed")
+
+// PathParameters ...
+type PathParameters map[string]string
+
+// SetPathParameters ...
+func SetPathParameters(
+	request *http.Request,
+	parameters PathParameters,
+) *http.Request {
+	ctx := context.WithValue(request.Context(), pathParametersKey{}, parameters)
+	return request.WithContext(ctx)
+}
+
+// GetPathParameters ...
+func GetPathParameters(request *http.Request) PathParameters {
+	parameters, _ := request.Context().Value(pathParametersKey{}).(PathParameters)
+	return parameters
+}
+
+// GetString ...
+func (parameters PathParameters) GetString(name string) (string, error) {
+	value, ok := parameters[name]
+	if !ok || value == "" {
+		return "", fmt.Errorf("%s: %w", name, ErrParameterIsMissed)
+	}
+
+	return value, nil
+}
+
+// GetInt ...
+func (parameters PathParameters) GetInt(name string) (int, error) {
+	value, err := parameters.GetString(name)
+	if err != nil {
+		return 0, err
+	}
+
+	valueAsInt, err := strconv.Atoi(value)
+	if err != nil {
+		return 0, fmt.Errorf("unable to parse the %s parameter: %w", name, err)
+	}
+
+	return valueAsInt, nil
+}
+
+// GetInt64 ...
+func (parameters PathParameters) GetInt64(name string) (int64, error) {
+	value, err := parameters.GetString(name)
+	if err != nil {
+		return 0, err
+	}
+
+	valueAsInt64, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("unable to parse the %s parameter: %w", name, err)
+	}
+
+	return valueAsInt64, nil
+}
+
+// GetDate ...
+func (parameters PathParameters) GetDate(name string) (models.Date, error) {
+	value, err := parameters.GetString(name)
+	if err != nil {
+		return models.Date{}, err
+	}
+
+	date, err := models.ParseDate(value)
+	if err != nil {
+		return models.Date{},
+			fmt.Errorf("unable to parse the %s parameter: %w", name, err)
+	}
+
+	return date, nil
+}
+
+// GetDateRange ...
+func (parameters PathParameters) GetDateRange(
+	name string,
+) (models.DateRange, error) {
+	value, err := parameters.GetString(name)
+	if err != nil {
+		return models.DateRange{}, err
+	}
+
+	dateRange, err := models.ParseDateRange(value)
+	if err != nil {
+		return models.DateRange{},
+			fmt.Errorf("unable to parse the %s parameter: %w", name, err)
+	}
+
+	return dateRange, nil
+}
+
+// RouteTemplate ...
+type RouteTemplate struct {
+	template       string
+	pattern        *regexp.Regexp
+	parameterNames []string
+}
+
+// ParseRouteTemplate ...
+func ParseRouteTemplate(template string) (RouteTemplate, error) {
+	if !strings.HasPrefix(template, "/") {
+		return RouteTemplate{}, errors.New("template should start with a slash")
+	}
+
+	var patternBuilder strings.Builder
+	patternBuilder.WriteString("^")
+
+	var parameterNames []string
+	knownParameterNames := map[string]struct{}{}
+	rest := strings.TrimSuffix(template, "/")
+	for rest != "" {
+		openingIndex := strings.IndexAny(rest, "{}")
+		if openingIndex == -1 {
+			patternBuilder.WriteString(regexp.QuoteMeta(rest))
+			break
+		}
+		if rest[openingIndex] == '}' {
+			return RouteTemplate{}, errors.New("unexpected closing brace")
+		}
+
+		patternBuilder.WriteString(regexp.QuoteMeta(rest[:openingIndex]))
+		rest = rest[openingIndex+1:]
+
+		closingIndex := strings.IndexAny(rest, "{}")
+		if closingIndex == -1 || rest[closingIndex] == '{' {
+			return RouteTemplate{}, errors.New("unclosed brace")
+		}
+
+		name, parameterType := rest[:closingIndex], ""
+		if separatorIndex := strings.Index(name, ":"); separatorIndex != -1 {
+			name, parameterType = name[:separatorIndex], name[separatorIndex+1:]
+		}
+		if !parameterNamePattern.MatchString(name) {
+			return RouteTemplate{}, fmt.Errorf("incorrect parameter name %q", name)
+		}
+		if _, ok := knownParameterNames[name]; ok {
+			return RouteTemplate{}, fmt.Errorf("duplicate parameter name %q", name)
+		}
+
+		parameterPattern, ok := parameterTypePatterns[parameterType]
+		if !ok {
+			return RouteTemplate{},
+				fmt.Errorf("unknown parameter type %q", parameterType)
+		}
+
+		patternBuilder.WriteString("(" + parameterPattern + ")")
+		parameterNames = append(parameterNames, name)
+		knownParameterNames[name] = struct{}{}
+
+		rest = rest[closingIndex+1:]
+	}
+
+	patternBuilder.WriteString("/?$")
+
+	pattern, err := regexp.Compile(patternBuilder.String())
+	if err != nil {
+		return RouteTemplate{}, fmt.Errorf("unable to compile the pattern: %w", err)
+	}
+
+	routeTemplate := RouteTemplate{
+		template:       template,
+		pattern:        pattern,
+		parameterNames: parameterNames,
+	}
+	return routeTemplate, nil
+}
+
+// MustParseRouteTemplate ...
+func MustParseRouteTemplate(template string) RouteTemplate {
+	routeTemplate, err := ParseRouteTemplate(template)
+	if err != nil {
+		panic(fmt.Sprintf("unable to parse the route template %q: %s", template, err))
+	}
+
+	return routeTemplate
+}
+
+// String ...
+func (template RouteTemplate) String() string {
+	return template.template
+}
+
+// Match ...
+func (template RouteTemplate) Match(path string) (PathParameters, bool) {
+	submatches := template.pattern.FindStringSubmatch(path)
+	if submatches == nil {
+		return nil, false
+	}
+
+	parameters := make(PathParameters, len(template.parameterNames))
+	for index, name := range template.parameterNames {
+		parameters[name] = submatches[index+1]
+	}
+
+	return parameters, true
+}
+
+// MatchRequest ...
+func (template RouteTemplate) MatchRequest(
+	request *http.Request,
+) (*http.Request, bool) {
+	parameters, ok := template.Match(request.URL.Path)
+	if !ok {
+		return request, false
+	}
+
+	return SetPathParameters(request, parameters), true
+}
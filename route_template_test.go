@@ -0,0 +1,379 @@
+package httputils
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/irenicaa/go-http-utils/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPathParameters_GetInt(t *testing.T) {
+	type args struct {
+		name string
+	}
+
+	tests := []struct {
+		name       string
+		parameters PathParameters
+		args       args
+		want       int
+		wantErr    assert.ErrorAssertionFunc
+	}{
+		{
+			name:       "success",
+			parameters: PathParameters{"userID": "23"},
+			args:       args{name: "userID"},
+			want:       23,
+			wantErr:    assert.NoError,
+		},
+		{
+			name:       "error with a missed parameter",
+			parameters: PathParameters{"userID": "23"},
+			args:       args{name: "postID"},
+			want:       0,
+			wantErr: func(t assert.TestingT, err error, msgAndArgs ...interface{}) bool {
+				return assert.ErrorIs(t, err, ErrParameterIsMissed, msgAndArgs...)
+			},
+		},
+		{
+			name:       "error with a malformed parameter",
+			parameters: PathParameters{"userID": "value"},
+			args:       args{name: "userID"},
+			want:       0,
+			wantErr:    assert.Error,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.parameters.GetInt(tt.args.name)
+
+			assert.Equal(t, tt.want, got)
+			tt.wantErr(t, err)
+		})
+	}
+}
+
+func TestPathParameters_GetInt64(t *testing.T) {
+	type args struct {
+		name string
+	}
+
+	tests := []struct {
+		name       string
+		parameters PathParameters
+		args       args
+		want       int64
+		wantErr    assert.ErrorAssertionFunc
+	}{
+		{
+			name:       "success",
+			parameters: PathParameters{"userID": "9007199254740993"},
+			args:       args{name: "userID"},
+			want:       9007199254740993,
+			wantErr:    assert.NoError,
+		},
+		{
+			name:       "error with a missed parameter",
+			parameters: PathParameters{"userID": "23"},
+			args:       args{name: "postID"},
+			want:       0,
+			wantErr: func(t assert.TestingT, err error, msgAndArgs ...interface{}) bool {
+				return assert.ErrorIs(t, err, ErrParameterIsMissed, msgAndArgs...)
+			},
+		},
+		{
+			name:       "error with a malformed parameter",
+			parameters: PathParameters{"userID": "value"},
+			args:       args{name: "userID"},
+			want:       0,
+			wantErr:    assert.Error,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.parameters.GetInt64(tt.args.name)
+
+			assert.Equal(t, tt.want, got)
+			tt.wantErr(t, err)
+		})
+	}
+}
+
+func TestPathParameters_GetDate(t *testing.T) {
+	type args struct {
+		name string
+	}
+
+	tests := []struct {
+		name       string
+		parameters PathParameters
+		args       args
+		want       models.Date
+		wantErr    assert.ErrorAssertionFunc
+	}{
+		{
+			name:       "success",
+			parameters: PathParameters{"day": "2006-01-02"},
+			args:       args{name: "day"},
+			want: models.Date(
+				time.Date(2006, time.January, 2, 0, 0, 0, 0, time.UTC),
+			),
+			wantErr: assert.NoError,
+		},
+		{
+			name:       "error with a missed parameter",
+			parameters: PathParameters{},
+			args:       args{name: "day"},
+			want:       models.Date{},
+			wantErr: func(t assert.TestingT, err error, msgAndArgs ...interface{}) bool {
+				return assert.ErrorIs(t, err, ErrParameterIsMissed, msgAndArgs...)
+			},
+		},
+		{
+			name:       "error with a malformed parameter",
+			parameters: PathParameters{"day": "9999-99-99"},
+			args:       args{name: "day"},
+			want:       models.Date{},
+			wantErr:    assert.Error,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.parameters.GetDate(tt.args.name)
+
+			assert.Equal(t, tt.want, got)
+			tt.wantErr(t, err)
+		})
+	}
+}
+
+func TestPathParameters_GetDateRange(t *testing.T) {
+	type args struct {
+		name string
+	}
+
+	tests := []struct {
+		name       string
+		parameters PathParameters
+		args       args
+		want       models.DateRange
+		wantErr    assert.ErrorAssertionFunc
+	}{
+		{
+			name:       "success",
+			parameters: PathParameters{"period": "2006-01-02..2006-01-05"},
+			args:       args{name: "period"},
+			want: models.NewDateRange(
+				models.Date(time.Date(2006, time.January, 2, 0, 0, 0, 0, time.UTC)),
+				models.Date(time.Date(2006, time.January, 5, 0, 0, 0, 0, time.UTC)),
+			),
+			wantErr: assert.NoError,
+		},
+		{
+			name:       "error with a missed parameter",
+			parameters: PathParameters{},
+			args:       args{name: "period"},
+			want:       models.DateRange{},
+			wantErr: func(t assert.TestingT, err error, msgAndArgs ...interface{}) bool {
+				return assert.ErrorIs(t, err, ErrParameterIsMissed, msgAndArgs...)
+			},
+		},
+		{
+			name:       "error with a malformed parameter",
+			parameters: PathParameters{"period": "2006-01-05..2006-01-02"},
+			args:       args{name: "period"},
+			want:       models.DateRange{},
+			wantErr: func(t assert.TestingT, err error, msgAndArgs ...interface{}) bool {
+				return assert.ErrorIs(t, err, models.ErrInvalidDateRange, msgAndArgs...)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.parameters.GetDateRange(tt.args.name)
+
+			assert.Equal(t, tt.want, got)
+			tt.wantErr(t, err)
+		})
+	}
+}
+
+func TestParseRouteTemplate(t *testing.T) {
+	type args struct {
+		template string
+	}
+
+	tests := []struct {
+		name    string
+		args    args
+		wantErr assert.ErrorAssertionFunc
+	}{
+		{
+			name:    "success",
+			args:    args{template: "/users/{userID:int}/posts/{postID:int}/{day:date}"},
+			wantErr: assert.NoError,
+		},
+		{
+			name:    "error without a leading slash",
+			args:    args{template: "users/{userID:int}"},
+			wantErr: assert.Error,
+		},
+		{
+			name:    "error with an unexpected closing brace",
+			args:    args{template: "/users/userID}"},
+			wantErr: assert.Error,
+		},
+		{
+			name:    "error with an unclosed brace",
+			args:    args{template: "/users/{userID"},
+			wantErr: assert.Error,
+		},
+		{
+			name:    "error with an incorrect parameter name",
+			args:    args{template: "/users/{user-id}"},
+			wantErr: assert.Error,
+		},
+		{
+			name:    "error with a duplicate parameter name",
+			args:    args{template: "/users/{id:int}/posts/{id:int}"},
+			wantErr: assert.Error,
+		},
+		{
+			name:    "error with an unknown parameter type",
+			args:    args{template: "/users/{userID:unknown}"},
+			wantErr: assert.Error,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseRouteTemplate(tt.args.template)
+
+			tt.wantErr(t, err)
+		})
+	}
+}
+
+func TestRouteTemplate_Match(t *testing.T) {
+	type args struct {
+		path string
+	}
+
+	tests := []struct {
+		name           string
+		template       string
+		args           args
+		wantParameters PathParameters
+		wantOk         assert.BoolAssertionFunc
+	}{
+		{
+			name:     "success with several parameters",
+			template: "/users/{userID:int}/posts/{postID:int}/{day:date}",
+			args:     args{path: "/users/12/posts/5/2022-01-02"},
+			wantParameters: PathParameters{
+				"userID": "12",
+				"postID": "5",
+				"day":    "2022-01-02",
+			},
+			wantOk: assert.True,
+		},
+		{
+			name:     "success with identifier parameters",
+			template: "/orgs/{orgID:uuid}/events/{eventID:ulid}/{slug:slug}",
+			args: args{
+				path: "/orgs/123e4567-e89b-12d3-a456-426614174000" +
+					"/events/01ARZ3NDEKTSV4RRFFQ69G5FAV/summer-party",
+			},
+			wantParameters: PathParameters{
+				"orgID":   "123e4567-e89b-12d3-a456-426614174000",
+				"eventID": "01ARZ3NDEKTSV4RRFFQ69G5FAV",
+				"slug":    "summer-party",
+			},
+			wantOk: assert.True,
+		},
+		{
+			name:     "success with open-ended date ranges",
+			template: "/reports/{since:daterange}/{until:daterange}",
+			args:     args{path: "/reports/2022-01-01../..2022-01-31"},
+			wantParameters: PathParameters{
+				"since": "2022-01-01..",
+				"until": "..2022-01-31",
+			},
+			wantOk: assert.True,
+		},
+		{
+			name:           "failure with a date range without bounds",
+			template:       "/reports/{period:daterange}",
+			args:           args{path: "/reports/.."},
+			wantParameters: nil,
+			wantOk:         assert.False,
+		},
+		{
+			name:           "success with a string parameter",
+			template:       "/files/{name}.json",
+			args:           args{path: "/files/report.json"},
+			wantParameters: PathParameters{"name": "report"},
+			wantOk:         assert.True,
+		},
+		{
+			name:           "success with a trailing slash",
+			template:       "/users/{userID:int}",
+			args:           args{path: "/users/12/"},
+			wantParameters: PathParameters{"userID": "12"},
+			wantOk:         assert.True,
+		},
+		{
+			name:           "success with a root",
+			template:       "/",
+			args:           args{path: "/"},
+			wantParameters: PathParameters{},
+			wantOk:         assert.True,
+		},
+		{
+			name:           "failure with a type mismatch",
+			template:       "/users/{userID:int}",
+			args:           args{path: "/users/alice"},
+			wantParameters: nil,
+			wantOk:         assert.False,
+		},
+		{
+			name:           "failure with an extra segment",
+			template:       "/users/{userID:int}",
+			args:           args{path: "/users/12/posts"},
+			wantParameters: nil,
+			wantOk:         assert.False,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			template, err := ParseRouteTemplate(tt.template)
+			require.NoError(t, err)
+
+			gotParameters, gotOk := template.Match(tt.args.path)
+
+			assert.Equal(t, tt.wantParameters, gotParameters)
+			tt.wantOk(t, gotOk)
+		})
+	}
+}
+
+func TestRouteTemplate_MatchRequest(t *testing.T) {
+	template := MustParseRouteTemplate("/users/{userID:int}/posts/{id:int}")
+	request := httptest.NewRequest(
+		http.MethodGet,
+		"http://example.com/users/12/posts/5",
+		nil,
+	)
+
+	gotRequest, gotOk := template.MatchRequest(request)
+	require.True(t, gotOk)
+
+	id, err := GetIDFromURL(gotRequest)
+	require.NoError(t, err)
+
+	assert.Equal(t, PathParameters{"userID": "12", "id": "5"}, GetPathParameters(gotRequest))
+	assert.Equal(t, 5, id)
+}
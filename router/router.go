@@ -0,0 +1,189 @@
+package router
+
+import (
+	"net/http"
+	"sort"
+	"strings"
+
+	httputils "github.com/irenicaa/go-http-utils"
+)
+
+// Middleware ...
+type Middleware func(handler http.Handler) http.Handler
+
+type route struct {
+	method   string
+	template httputils.RouteTemplate
+	handler  http.Handler
+	router   *Router
+}
+
+type routeTable struct {
+	root   *Router
+	routes []route
+	logger httputils.Logger
+}
+
+// Router ...
+//
+// Middlewares are resolved when a request is served,
+// so ones added with Use apply to the routes and groups registered before;
+// the middlewares of the root router also apply to unmatched requests.
+// The router shouldn't be changed concurrently with serving.
+type Router struct {
+	table       *routeTable
+	parent      *Router
+	prefix      string
+	middlewares []Middleware
+}
+
+// NewRouter ...
+func NewRouter(logger httputils.Logger) *Router {
+	table := &routeTable{logger: logger}
+	table.root = &Router{table: table}
+
+	return table.root
+}
+
+// Use ...
+func (router *Router) Use(middlewares ...Middleware) {
+	router.middlewares = append(router.middlewares, middlewares...)
+}
+
+// Group ...
+func (router *Router) Group(prefix string, middlewares ...Middleware) *Router {
+	groupMiddlewares := make([]Middleware, len(middlewares))
+	copy(groupMiddlewares, middlewares)
+
+	return &Router{
+		table:       router.table,
+		parent:      router,
+		prefix:      joinPaths(router.prefix, prefix),
+		middlewares: groupMiddlewares,
+	}
+}
+
+// Handle ...
+func (router *Router) Handle(
+	method string,
+	template string,
+	handler http.Handler,
+) {
+	router.table.routes = append(router.table.routes, route{
+		method: method,
+		template: httputils.MustParseRouteTemplate(
+			joinPaths(router.prefix, template),
+		),
+		handler: handler,
+		router:  router,
+	})
+}
+
+// HandleFunc ...
+func (router *Router) HandleFunc(
+	method string,
+	template string,
+	handler http.HandlerFunc,
+) {
+	router.Handle(method, template, handler)
+}
+
+// ServeHTTP ...
+func (router *Router) ServeHTTP(
+	writer http.ResponseWriter,
+	request *http.Request,
+) {
+	var pathMatchedRoute *route
+	var allowedMethods []string
+	for index := range router.table.routes {
+		route := &router.table.routes[index]
+		parameters, ok := route.template.Match(request.URL.Path)
+		if !ok {
+			continue
+		}
+
+		if route.method == request.Method ||
+			(route.method == http.MethodGet && request.Method == http.MethodHead) {
+			request = httputils.SetPathParameters(request, parameters)
+			route.router.wrapHandler(route.handler).ServeHTTP(writer, request)
+
+			return
+		}
+
+		if pathMatchedRoute == nil {
+			pathMatchedRoute = route
+		}
+
+		allowedMethods = append(allowedMethods, route.method)
+		if route.method == http.MethodGet {
+			allowedMethods = append(allowedMethods, http.MethodHead)
+		}
+	}
+
+	if pathMatchedRoute == nil {
+		handler := http.HandlerFunc(func(
+			writer http.ResponseWriter,
+			request *http.Request,
+		) {
+			httputils.HandleError(
+				writer,
+				router.table.logger,
+				http.StatusNotFound,
+				"unable to find the route: %s",
+				request.URL.Path,
+			)
+		})
+		router.table.root.wrapHandler(handler).ServeHTTP(writer, request)
+
+		return
+	}
+
+	allowHeader := strings.Join(uniqueSortedStrings(allowedMethods), ", ")
+	handler := http.HandlerFunc(func(
+		writer http.ResponseWriter,
+		request *http.Request,
+	) {
+		writer.Header().Set("Allow", allowHeader)
+		httputils.HandleError(
+			writer,
+			router.table.logger,
+			http.StatusMethodNotAllowed,
+			"method %s is not allowed for the route: %s",
+			request.Method,
+			request.URL.Path,
+		)
+	})
+	pathMatchedRoute.router.wrapHandler(handler).ServeHTTP(writer, request)
+}
+
+// the middlewares of the parents are outer ones
+func (router *Router) wrapHandler(handler http.Handler) http.Handler {
+	for current := router; current != nil; current = current.parent {
+		for index := len(current.middlewares) - 1; index >= 0; index-- {
+			handler = current.middlewares[index](handler)
+		}
+	}
+
+	return handler
+}
+
+func joinPaths(prefix string, path string) string {
+	if prefix == "" {
+		return path
+	}
+
+	return strings.TrimSuffix(prefix, "/") + "/" + strings.TrimPrefix(path, "/")
+}
+
+func uniqueSortedStrings(values []string) []string {
+	sort.Strings(values)
+
+	var uniqueValues []string
+	for _, value := range values {
+		if len(uniqueValues) == 0 || uniqueValues[len(uniqueValues)-1] != value {
+			uniqueValues = append(uniqueValues, value)
+		}
+	}
+
+	return uniqueValues
+}
@@ -0,0 +1,323 @@
+package router
+
+import (
+	"bytes"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	httputils "github.com/irenicaa/go-http-utils"
+	"github.com/irenicaa/go-http-utils/middlewares"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRouter(t *testing.T) {
+	makeRouter := func(logger httputils.Logger) *Router {
+		router := NewRouter(logger)
+		router.HandleFunc(
+			http.MethodGet,
+			"/users/{userID:int}/posts/{postID:int}",
+			func(writer http.ResponseWriter, request *http.Request) {
+				parameters := httputils.GetPathParameters(request)
+				writer.Write([]byte(parameters["userID"] + " " + parameters["postID"]))
+			},
+		)
+		router.HandleFunc(
+			http.MethodDelete,
+			"/users/{userID:int}/posts/{postID:int}",
+			func(writer http.ResponseWriter, request *http.Request) {
+				writer.WriteHeader(http.StatusNoContent)
+			},
+		)
+
+		group := router.Group("/api/v1", middlewares.CORSMiddleware)
+		group.HandleFunc(
+			http.MethodPost,
+			"/todos",
+			func(writer http.ResponseWriter, request *http.Request) {
+				writer.WriteHeader(http.StatusCreated)
+			},
+		)
+
+		return router
+	}
+
+	type args struct {
+		request *http.Request
+	}
+
+	tests := []struct {
+		name         string
+		logger       httputils.Logger
+		args         args
+		wantResponse *http.Response
+	}{
+		{
+			name:   "success with path parameters",
+			logger: &MockLogger{},
+			args: args{
+				request: httptest.NewRequest(
+					http.MethodGet,
+					"http://example.com/users/12/posts/5",
+					nil,
+				),
+			},
+			wantResponse: &http.Response{
+				Status: strconv.Itoa(http.StatusOK) + " " +
+					http.StatusText(http.StatusOK),
+				StatusCode: http.StatusOK,
+				Proto:      "HTTP/1.1",
+				ProtoMajor: 1,
+				ProtoMinor: 1,
+				Header: http.Header{
+					"Content-Type": {"text/plain; charset=utf-8"},
+				},
+				Body:          ioutil.NopCloser(bytes.NewReader([]byte("12 5"))),
+				ContentLength: -1,
+			},
+		},
+		{
+			name:   "success with the HEAD method",
+			logger: &MockLogger{},
+			args: args{
+				request: httptest.NewRequest(
+					http.MethodHead,
+					"http://example.com/users/12/posts/5",
+					nil,
+				),
+			},
+			wantResponse: &http.Response{
+				Status: strconv.Itoa(http.StatusOK) + " " +
+					http.StatusText(http.StatusOK),
+				StatusCode: http.StatusOK,
+				Proto:      "HTTP/1.1",
+				ProtoMajor: 1,
+				ProtoMinor: 1,
+				Header: http.Header{
+					"Content-Type": {"text/plain; charset=utf-8"},
+				},
+				Body:          ioutil.NopCloser(bytes.NewReader([]byte("12 5"))),
+				ContentLength: -1,
+			},
+		},
+		{
+			name:   "success with a group",
+			logger: &MockLogger{},
+			args: args{
+				request: func() *http.Request {
+					request := httptest.NewRequest(
+						http.MethodPost,
+						"http://example.com/api/v1/todos",
+						nil,
+					)
+					request.Header.Set("Origin", "http://example.com")
+
+					return request
+				}(),
+			},
+			wantResponse: &http.Response{
+				Status: strconv.Itoa(http.StatusCreated) + " " +
+					http.StatusText(http.StatusCreated),
+				StatusCode: http.StatusCreated,
+				Proto:      "HTTP/1.1",
+				ProtoMajor: 1,
+				ProtoMinor: 1,
+				Header: http.Header{
+					"Access-Control-Allow-Origin":  {"http://example.com"},
+					"Access-Control-Allow-Methods": {""},
+					"Access-Control-Allow-Headers": {""},
+				},
+				Body:          ioutil.NopCloser(bytes.NewReader(nil)),
+				ContentLength: -1,
+			},
+		},
+		{
+			name:   "success with a CORS preflight request",
+			logger: &MockLogger{},
+			args: args{
+				request: func() *http.Request {
+					request := httptest.NewRequest(
+						http.MethodOptions,
+						"http://example.com/api/v1/todos",
+						nil,
+					)
+					request.Header.Set("Origin", "http://example.com")
+					request.Header.Set("Access-Control-Request-Method", http.MethodPost)
+
+					return request
+				}(),
+			},
+			wantResponse: &http.Response{
+				Status: strconv.Itoa(http.StatusOK) + " " +
+					http.StatusText(http.StatusOK),
+				StatusCode: http.StatusOK,
+				Proto:      "HTTP/1.1",
+				ProtoMajor: 1,
+				ProtoMinor: 1,
+				Header: http.Header{
+					"Access-Control-Allow-Origin":  {"http://example.com"},
+					"Access-Control-Allow-Methods": {http.MethodPost},
+					"Access-Control-Allow-Headers": {""},
+				},
+				Body:          ioutil.NopCloser(bytes.NewReader(nil)),
+				ContentLength: -1,
+			},
+		},
+		{
+			name: "error with an unknown route",
+			logger: func() httputils.Logger {
+				logger := &MockLogger{}
+				logger.InnerMock.
+					On("Print", []interface{}{"unable to find the route: /unknown"}).
+					Return().
+					Times(1)
+
+				return logger
+			}(),
+			args: args{
+				request: httptest.NewRequest(
+					http.MethodGet,
+					"http://example.com/unknown",
+					nil,
+				),
+			},
+			wantResponse: &http.Response{
+				Status: strconv.Itoa(http.StatusNotFound) + " " +
+					http.StatusText(http.StatusNotFound),
+				StatusCode: http.StatusNotFound,
+				Proto:      "HTTP/1.1",
+				ProtoMajor: 1,
+				ProtoMinor: 1,
+				Header:     http.Header{},
+				Body: ioutil.NopCloser(bytes.NewReader(
+					[]byte("unable to find the route: /unknown"),
+				)),
+				ContentLength: -1,
+			},
+		},
+		{
+			name: "error with a disallowed method",
+			logger: func() httputils.Logger {
+				logger := &MockLogger{}
+				logger.InnerMock.
+					On("Print", []interface{}{
+						"method PUT is not allowed for the route: /users/12/posts/5",
+					}).
+					Return().
+					Times(1)
+
+				return logger
+			}(),
+			args: args{
+				request: httptest.NewRequest(
+					http.MethodPut,
+					"http://example.com/users/12/posts/5",
+					nil,
+				),
+			},
+			wantResponse: &http.Response{
+				Status: strconv.Itoa(http.StatusMethodNotAllowed) + " " +
+					http.StatusText(http.StatusMethodNotAllowed),
+				StatusCode: http.StatusMethodNotAllowed,
+				Proto:      "HTTP/1.1",
+				ProtoMajor: 1,
+				ProtoMinor: 1,
+				Header:     http.Header{"Allow": {"DELETE, GET, HEAD"}},
+				Body: ioutil.NopCloser(bytes.NewReader([]byte(
+					"method PUT is not allowed for the route: /users/12/posts/5",
+				))),
+				ContentLength: -1,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			responseRecorder := httptest.NewRecorder()
+			makeRouter(tt.logger).ServeHTTP(responseRecorder, tt.args.request)
+
+			tt.logger.(*MockLogger).InnerMock.AssertExpectations(t)
+			assert.Equal(t, tt.wantResponse, responseRecorder.Result())
+		})
+	}
+}
+
+func TestRouter_Use(t *testing.T) {
+	makeMiddleware := func(name string) Middleware {
+		return func(handler http.Handler) http.Handler {
+			return http.HandlerFunc(func(
+				writer http.ResponseWriter,
+				request *http.Request,
+			) {
+				writer.Header().Add("X-Middlewares", name)
+				handler.ServeHTTP(writer, request)
+			})
+		}
+	}
+
+	logger := &MockLogger{}
+	logger.InnerMock.
+		On("Print", []interface{}{"unable to find the route: /unknown"}).
+		Return().
+		Times(1)
+
+	router := NewRouter(logger)
+	router.HandleFunc(
+		http.MethodGet,
+		"/users",
+		func(writer http.ResponseWriter, request *http.Request) {},
+	)
+
+	group := router.Group("/api/v1", makeMiddleware("group"))
+	group.HandleFunc(
+		http.MethodGet,
+		"/todos",
+		func(writer http.ResponseWriter, request *http.Request) {},
+	)
+
+	// the middlewares are added after the routes and the group
+	router.Use(makeMiddleware("root"))
+	group.Use(makeMiddleware("late group"))
+
+	tests := []struct {
+		name            string
+		path            string
+		wantMiddlewares []string
+	}{
+		{
+			name:            "with a root route",
+			path:            "/users",
+			wantMiddlewares: []string{"root"},
+		},
+		{
+			name:            "with a group route",
+			path:            "/api/v1/todos",
+			wantMiddlewares: []string{"root", "group", "late group"},
+		},
+		{
+			name:            "with an unknown route",
+			path:            "/unknown",
+			wantMiddlewares: []string{"root"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			responseRecorder := httptest.NewRecorder()
+			request := httptest.NewRequest(
+				http.MethodGet,
+				"http://example.com"+tt.path,
+				nil,
+			)
+			router.ServeHTTP(responseRecorder, request)
+
+			assert.Equal(
+				t,
+				tt.wantMiddlewares,
+				responseRecorder.Result().Header["X-Middlewares"],
+			)
+		})
+	}
+
+	logger.InnerMock.AssertExpectations(t)
+}
@@ -0,0 +1,199 @@
+package httputils
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/irenicaa/go-http-utils/models"
+)
+
+// ...
+var (
+	ErrFieldIsNotAllowed    = errors.New("field is not allowed")
+	ErrOperatorIsNotAllowed = errors.New("operator is not allowed")
+)
+
+// FieldType ...
+type FieldType int
+
+// ...
+const (
+	StringFieldType FieldType = iota
+	IntFieldType
+	DateFieldType
+)
+
+// FilterOperator ...
+type FilterOperator string
+
+// ...
+const (
+	EqualOperator          FilterOperator = "eq"
+	NotEqualOperator       FilterOperator = "ne"
+	GreaterOperator        FilterOperator = "gt"
+	GreaterOrEqualOperator FilterOperator = "gte"
+	LessOperator           FilterOperator = "lt"
+	LessOrEqualOperator    FilterOperator = "lte"
+	InOperator             FilterOperator = "in"
+	LikeOperator           FilterOperator = "like"
+)
+
+const inOperatorSeparator = ","
+
+var fieldTypeOperators = map[FieldType][]FilterOperator{
+	StringFieldType: {
+		EqualOperator,
+		NotEqualOperator,
+		InOperator,
+		LikeOperator,
+	},
+	IntFieldType: {
+		EqualOperator,
+		NotEqualOperator,
+		GreaterOperator,
+		GreaterOrEqualOperator,
+		LessOperator,
+		LessOrEqualOperator,
+		InOperator,
+	},
+	DateFieldType: {
+		EqualOperator,
+		NotEqualOperator,
+		GreaterOperator,
+		GreaterOrEqualOperator,
+		LessOperator,
+		LessOrEqualOperator,
+		InOperator,
+	},
+}
+
+// SortField ...
+type SortField struct {
+	Field        string
+	IsDescending bool
+}
+
+// FilterCondition ...
+//
+// Values contain ints, strings or models.Date depending on the field type;
+// there are several of them only for the in operator.
+type FilterCondition struct {
+	Field    string
+	Operator FilterOperator
+	Values   []interface{}
+}
+
+func makeFilterKeyPattern(key string) *regexp.Regexp {
+	return regexp.MustCompile(
+		`^` + regexp.QuoteMeta(key) + `\[([^\[\]]+)\](?:\[([^\[\]]+)\])?$`,
+	)
+}
+
+func parseSortFields(
+	values []string,
+	allowedFields []string,
+) ([]SortField, error) {
+	var sortFields []SortField
+	usedFields := map[string]bool{}
+	for _, value := range values {
+		for _, field := range strings.Split(value, ",") {
+			field = strings.TrimSpace(field)
+			if field == "" {
+				continue
+			}
+
+			sortField := SortField{Field: field}
+			if strings.HasPrefix(field, "-") {
+				sortField = SortField{Field: field[1:], IsDescending: true}
+			}
+			if !containsString(allowedFields, sortField.Field) {
+				return nil, fmt.Errorf("%q: %w", sortField.Field, ErrFieldIsNotAllowed)
+			}
+			if usedFields[sortField.Field] {
+				return nil, fmt.Errorf("%q: field is duplicated", sortField.Field)
+			}
+
+			usedFields[sortField.Field] = true
+			sortFields = append(sortFields, sortField)
+		}
+	}
+
+	return sortFields, nil
+}
+
+func parseFilterCondition(
+	field string,
+	operator FilterOperator,
+	value string,
+	fieldType FieldType,
+) (FilterCondition, error) {
+	if !containsOperator(fieldTypeOperators[fieldType], operator) {
+		return FilterCondition{}, fmt.Errorf("%q: %w", operator, ErrOperatorIsNotAllowed)
+	}
+
+	rawValues := []string{value}
+	if operator == InOperator {
+		rawValues = strings.Split(value, inOperatorSeparator)
+	}
+
+	values := make([]interface{}, 0, len(rawValues))
+	for index, rawValue := range rawValues {
+		parsedValue, err := parseFilterValue(rawValue, fieldType)
+		if err != nil {
+			if operator == InOperator {
+				err = fmt.Errorf("element #%d: %w", index, err)
+			}
+
+			return FilterCondition{}, err
+		}
+
+		values = append(values, parsedValue)
+	}
+
+	condition := FilterCondition{Field: field, Operator: operator, Values: values}
+	return condition, nil
+}
+
+func parseFilterValue(value string, fieldType FieldType) (interface{}, error) {
+	switch fieldType {
+	case IntFieldType:
+		valueAsInt, err := strconv.Atoi(value)
+		if err != nil {
+			return nil, fmt.Errorf("value is incorrect: %w", err)
+		}
+
+		return valueAsInt, nil
+	case DateFieldType:
+		date, err := models.ParseDate(value)
+		if err != nil {
+			return nil, fmt.Errorf("unable to parse the date: %w", err)
+		}
+
+		return date, nil
+	default:
+		return value, nil
+	}
+}
+
+func containsString(values []string, value string) bool {
+	for _, someValue := range values {
+		if someValue == value {
+			return true
+		}
+	}
+
+	return false
+}
+
+func containsOperator(operators []FilterOperator, operator FilterOperator) bool {
+	for _, someOperator := range operators {
+		if someOperator == operator {
+			return true
+		}
+	}
+
+	return false
+}
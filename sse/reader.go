@@ -0,0 +1,186 @@
+package sse
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	httputils "github.com/irenicaa/go-http-utils"
+)
+
+// ...
+const (
+	DefaultEventName   = "message"
+	DefaultMaxLineSize = 1 << 20
+)
+
+// ReceivedEvent ...
+type ReceivedEvent struct {
+	ID    string
+	Name  string
+	Data  string
+	Retry time.Duration
+}
+
+// DecodeData ...
+func (event ReceivedEvent) DecodeData(data interface{}) error {
+	if err := json.Unmarshal([]byte(event.Data), data); err != nil {
+		return fmt.Errorf("unable to unmarshal the data: %w", err)
+	}
+
+	return nil
+}
+
+// Reader ...
+type Reader struct {
+	scanner     *bufio.Scanner
+	closer      io.Closer
+	lastEventID string
+	retry       time.Duration
+}
+
+// NewReader ...
+func NewReader(reader io.Reader) *Reader {
+	return NewReaderWithMaxLineSize(reader, DefaultMaxLineSize)
+}
+
+// NewReaderWithMaxLineSize ...
+//
+// Longer lines, e.g. the data ones of large events,
+// fail the reading with bufio.ErrTooLong.
+func NewReaderWithMaxLineSize(reader io.Reader, maxLineSize int) *Reader {
+	scanner := bufio.NewScanner(reader)
+	scanner.Buffer(nil, maxLineSize)
+
+	closer, _ := reader.(io.Closer)
+	return &Reader{scanner: scanner, closer: closer}
+}
+
+// LastEventID ...
+func (reader *Reader) LastEventID() string {
+	return reader.lastEventID
+}
+
+// Next ...
+func (reader *Reader) Next() (ReceivedEvent, error) {
+	var name string
+	var dataLines []string
+	for reader.scanner.Scan() {
+		line := strings.TrimSuffix(reader.scanner.Text(), "\r")
+		if line == "" {
+			if dataLines == nil {
+				name = ""
+				continue
+			}
+
+			if name == "" {
+				name = DefaultEventName
+			}
+
+			event := ReceivedEvent{
+				ID:    reader.lastEventID,
+				Name:  name,
+				Data:  strings.Join(dataLines, "\n"),
+				Retry: reader.retry,
+			}
+			return event, nil
+		}
+		if strings.HasPrefix(line, ":") {
+			continue
+		}
+
+		field, value := line, ""
+		if separatorIndex := strings.Index(line, ":"); separatorIndex != -1 {
+			field, value = line[:separatorIndex], line[separatorIndex+1:]
+			value = strings.TrimPrefix(value, " ")
+		}
+
+		switch field {
+		case "id":
+			if !strings.Contains(value, "\x00") {
+				reader.lastEventID = value
+			}
+		case "event":
+			name = value
+		case "data":
+			dataLines = append(dataLines, value)
+		case "retry":
+			if retry, err := strconv.ParseUint(value, 10, 63); err == nil {
+				reader.retry = time.Duration(retry) * time.Millisecond
+			}
+		}
+	}
+	if err := reader.scanner.Err(); err != nil {
+		return ReceivedEvent{}, fmt.Errorf("unable to read the stream: %w", err)
+	}
+
+	return ReceivedEvent{}, io.EOF
+}
+
+// Close ...
+func (reader *Reader) Close() error {
+	if reader.closer == nil {
+		return nil
+	}
+
+	return reader.closer.Close()
+}
+
+// Subscribe ...
+func Subscribe(
+	httpClient httputils.HTTPClient,
+	url string,
+	authHeader string,
+	lastEventID string,
+) (*Reader, error) {
+	return SubscribeWithContext(
+		context.Background(),
+		httpClient,
+		url,
+		authHeader,
+		lastEventID,
+		httputils.CallOptions{},
+	)
+}
+
+// SubscribeWithContext ...
+//
+// The call timeout limits the whole subscription
+// and is released when the reader is closed.
+func SubscribeWithContext(
+	ctx context.Context,
+	httpClient httputils.HTTPClient,
+	url string,
+	authHeader string,
+	lastEventID string,
+	options httputils.CallOptions,
+) (*Reader, error) {
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("unable to create the request: %w", err)
+	}
+
+	request.Header.Add("Accept", ContentType)
+	if authHeader != "" {
+		request.Header.Add("Authorization", authHeader)
+	}
+	if lastEventID != "" {
+		request.Header.Add(LastEventIDHeader, lastEventID)
+	}
+
+	response, err := httputils.DoStreamingRequest(httpClient, request, options)
+	if err != nil {
+		return nil, err
+	}
+
+	reader := NewReader(response.Body)
+	reader.lastEventID = lastEventID
+
+	return reader, nil
+}
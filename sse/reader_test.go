@@ -0,0 +1,221 @@
+package sse
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	httputils "github.com/irenicaa/go-http-utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type MockHTTPClient struct {
+	InnerMock mock.Mock
+}
+
+func (mock *MockHTTPClient) Do(request *http.Request) (*http.Response, error) {
+	results := mock.InnerMock.Called(request)
+	return results.Get(0).(*http.Response), results.Error(1)
+}
+
+func TestReader_Next(t *testing.T) {
+	type args struct {
+		reader io.Reader
+	}
+
+	tests := []struct {
+		name       string
+		args       args
+		wantEvents []ReceivedEvent
+		wantErr    assert.ErrorAssertionFunc
+	}{
+		{
+			name: "success",
+			args: args{
+				reader: strings.NewReader(
+					": heartbeat\n\n" +
+						"id: 1\r\nevent: progress\r\nretry: 5000\r\ndata: {\"progress\":10}\r\n\r\n" +
+						"data: first\ndata:second\n\n" +
+						"event: ignored\n\n" +
+						"id: 2\ndata: last",
+				),
+			},
+			wantEvents: []ReceivedEvent{
+				{
+					ID:    "1",
+					Name:  "progress",
+					Data:  `{"progress":10}`,
+					Retry: 5 * time.Second,
+				},
+				{
+					ID:    "1",
+					Name:  DefaultEventName,
+					Data:  "first\nsecond",
+					Retry: 5 * time.Second,
+				},
+			},
+			wantErr: assert.NoError,
+		},
+		{
+			name: "success with a large event",
+			args: args{
+				reader: strings.NewReader(
+					"data: " + strings.Repeat("x", 70<<10) + "\n\n",
+				),
+			},
+			wantEvents: []ReceivedEvent{
+				{Name: DefaultEventName, Data: strings.Repeat("x", 70<<10)},
+			},
+			wantErr: assert.NoError,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reader := NewReader(tt.args.reader)
+
+			var events []ReceivedEvent
+			var err error
+			for {
+				var event ReceivedEvent
+				if event, err = reader.Next(); err != nil {
+					break
+				}
+
+				events = append(events, event)
+			}
+			if errors.Is(err, io.EOF) {
+				err = nil
+			}
+
+			assert.Equal(t, tt.wantEvents, events)
+			tt.wantErr(t, err)
+		})
+	}
+}
+
+func TestNewReaderWithMaxLineSize(t *testing.T) {
+	reader := NewReaderWithMaxLineSize(
+		strings.NewReader("data: "+strings.Repeat("x", 100)+"\n\n"),
+		64,
+	)
+	_, err := reader.Next()
+
+	assert.ErrorIs(t, err, bufio.ErrTooLong)
+}
+
+func TestSubscribe(t *testing.T) {
+	type args struct {
+		httpClient  httputils.HTTPClient
+		url         string
+		authHeader  string
+		lastEventID string
+	}
+
+	tests := []struct {
+		name    string
+		args    args
+		wantErr assert.ErrorAssertionFunc
+	}{
+		{
+			name: "error with request creating",
+			args: args{
+				httpClient: &MockHTTPClient{},
+				url:        ":",
+			},
+			wantErr: assert.Error,
+		},
+		{
+			name: "error with the response status",
+			args: args{
+				httpClient: func() httputils.HTTPClient {
+					request, _ := http.NewRequest(http.MethodGet, "http://example.com/", nil)
+					request.Header.Add("Accept", ContentType)
+					request.Header.Add("Authorization", "Bearer token")
+					request.Header.Add(LastEventIDHeader, "23")
+
+					response := &http.Response{
+						StatusCode: http.StatusUnauthorized,
+						Body:       ioutil.NopCloser(bytes.NewReader([]byte("error"))),
+					}
+
+					httpClient := &MockHTTPClient{}
+					httpClient.InnerMock.On("Do", request).Return(response, nil).Times(1)
+
+					return httpClient
+				}(),
+				url:         "http://example.com/",
+				authHeader:  "Bearer token",
+				lastEventID: "23",
+			},
+			wantErr: func(t assert.TestingT, err error, msgAndArgs ...interface{}) bool {
+				return assert.EqualError(t, err, "request was failed: 401 error")
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Subscribe(
+				tt.args.httpClient,
+				tt.args.url,
+				tt.args.authHeader,
+				tt.args.lastEventID,
+			)
+
+			tt.args.httpClient.(*MockHTTPClient).InnerMock.AssertExpectations(t)
+			tt.wantErr(t, err)
+		})
+	}
+}
+
+func TestSubscribeWithContext(t *testing.T) {
+	var requestCtx context.Context
+	response := &http.Response{
+		StatusCode: http.StatusOK,
+		Body: ioutil.NopCloser(bytes.NewReader(
+			[]byte("id: 24\ndata: test\n\n"),
+		)),
+	}
+
+	httpClient := &MockHTTPClient{}
+	httpClient.InnerMock.
+		On("Do", mock.AnythingOfType("*http.Request")).
+		Run(func(args mock.Arguments) {
+			requestCtx = args.Get(0).(*http.Request).Context()
+		}).
+		Return(response, nil).
+		Times(1)
+
+	reader, err := SubscribeWithContext(
+		context.Background(),
+		httpClient,
+		"http://example.com/",
+		"",
+		"23",
+		httputils.CallOptions{Timeout: time.Minute},
+	)
+	require.NoError(t, err)
+
+	event, err := reader.Next()
+	require.NoError(t, err)
+	require.NoError(t, requestCtx.Err())
+
+	err = reader.Close()
+
+	httpClient.InnerMock.AssertExpectations(t)
+	assert.Equal(
+		t,
+		ReceivedEvent{ID: "24", Name: DefaultEventName, Data: "test"},
+		event,
+	)
+	assert.Equal(t, context.Canceled, requestCtx.Err())
+	assert.NoError(t, err)
+}
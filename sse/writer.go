@@ -0,0 +1,178 @@
+package sse
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	httputils "github.com/irenicaa/go-http-utils"
+)
+
+// ...
+const (
+	ContentType       = "text/event-stream"
+	LastEventIDHeader = "Last-Event-ID"
+)
+
+// Event ...
+type Event struct {
+	ID    string
+	Name  string
+	Data  interface{}
+	Retry time.Duration
+}
+
+// Writer ...
+type Writer struct {
+	writer  http.ResponseWriter
+	flusher http.Flusher
+	request *http.Request
+	logger  httputils.Logger
+}
+
+// NewWriter ...
+func NewWriter(
+	writer http.ResponseWriter,
+	request *http.Request,
+	logger httputils.Logger,
+) (*Writer, error) {
+	flusher, ok := writer.(http.Flusher)
+	if !ok {
+		return nil, errors.New("response writer doesn't support flushing")
+	}
+
+	writer.Header().Set("Content-Type", ContentType)
+	writer.Header().Set("Cache-Control", "no-cache")
+	writer.Header().Set("X-Accel-Buffering", "no")
+	writer.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	sseWriter := &Writer{
+		writer:  writer,
+		flusher: flusher,
+		request: request,
+		logger:  logger,
+	}
+	return sseWriter, nil
+}
+
+// LastEventID ...
+func (writer *Writer) LastEventID() string {
+	lastEventID := writer.request.Header.Get(LastEventIDHeader)
+	if lastEventID == "" {
+		lastEventID = writer.request.URL.Query().Get("lastEventId")
+	}
+
+	return lastEventID
+}
+
+// Send ...
+func (writer *Writer) Send(event Event) error {
+	var frame strings.Builder
+	if event.ID != "" {
+		if strings.ContainsAny(event.ID, "\r\n\x00") {
+			return errors.New("event ID contains forbidden symbols")
+		}
+
+		frame.WriteString("id: " + event.ID + "\n")
+	}
+	if event.Name != "" {
+		if strings.ContainsAny(event.Name, "\r\n") {
+			return errors.New("event name contains forbidden symbols")
+		}
+
+		frame.WriteString("event: " + event.Name + "\n")
+	}
+	if event.Retry > 0 {
+		retry := strconv.FormatInt(int64(event.Retry/time.Millisecond), 10)
+		frame.WriteString("retry: " + retry + "\n")
+	}
+	if event.Data != nil {
+		dataBytes, err := json.Marshal(event.Data)
+		if err != nil {
+			return fmt.Errorf("unable to marshal the data: %w", err)
+		}
+
+		for _, line := range strings.Split(string(dataBytes), "\n") {
+			frame.WriteString("data: " + line + "\n")
+		}
+	}
+	frame.WriteString("\n")
+
+	return writer.write(frame.String())
+}
+
+// Comment ...
+func (writer *Writer) Comment(text string) error {
+	var frame strings.Builder
+	for _, line := range strings.Split(text, "\n") {
+		frame.WriteString(": " + line + "\n")
+	}
+	frame.WriteString("\n")
+
+	return writer.write(frame.String())
+}
+
+// Heartbeat ...
+func (writer *Writer) Heartbeat() error {
+	return writer.Comment("heartbeat")
+}
+
+// Run ...
+func (writer *Writer) Run(
+	events <-chan Event,
+	heartbeatInterval time.Duration,
+) error {
+	var heartbeats <-chan time.Time
+	if heartbeatInterval > 0 {
+		ticker := time.NewTicker(heartbeatInterval)
+		defer ticker.Stop()
+
+		heartbeats = ticker.C
+	}
+
+	ctx := writer.request.Context()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case event, ok := <-events:
+			if !ok {
+				return nil
+			}
+
+			if err := writer.Send(event); err != nil {
+				return writer.handleError("unable to send the event", err)
+			}
+		case <-heartbeats:
+			if err := writer.Heartbeat(); err != nil {
+				return writer.handleError("unable to send the heartbeat", err)
+			}
+		}
+	}
+}
+
+func (writer *Writer) write(frame string) error {
+	if _, err := writer.writer.Write([]byte(frame)); err != nil {
+		return fmt.Errorf("unable to write the frame: %w", err)
+	}
+
+	writer.flusher.Flush()
+	return nil
+}
+
+func (writer *Writer) handleError(message string, err error) error {
+	err = fmt.Errorf("%s: %w", message, err)
+	writer.logger.Print(fmt.Sprintf(
+		"%s %s: %s",
+		writer.request.Method,
+		writer.request.URL,
+		err,
+	))
+
+	return err
+}
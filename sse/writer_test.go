@@ -0,0 +1,276 @@
+package sse
+
+import (
+	"bytes"
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/irenicaa/go-http-utils/middlewares"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWriter_Send(t *testing.T) {
+	type testData struct {
+		Progress int `json:"progress"`
+	}
+	type args struct {
+		event Event
+	}
+
+	tests := []struct {
+		name         string
+		args         args
+		wantResponse *http.Response
+		wantErr      assert.ErrorAssertionFunc
+	}{
+		{
+			name: "success with all fields",
+			args: args{
+				event: Event{
+					ID:    "23",
+					Name:  "progress",
+					Data:  testData{Progress: 42},
+					Retry: 5 * time.Second,
+				},
+			},
+			wantResponse: &http.Response{
+				Status: strconv.Itoa(http.StatusOK) + " " +
+					http.StatusText(http.StatusOK),
+				StatusCode: http.StatusOK,
+				Proto:      "HTTP/1.1",
+				ProtoMajor: 1,
+				ProtoMinor: 1,
+				Header: http.Header{
+					"Content-Type":      {ContentType},
+					"Cache-Control":     {"no-cache"},
+					"X-Accel-Buffering": {"no"},
+				},
+				Body: ioutil.NopCloser(bytes.NewReader([]byte(
+					"id: 23\nevent: progress\nretry: 5000\ndata: {\"progress\":42}\n\n",
+				))),
+				ContentLength: -1,
+			},
+			wantErr: assert.NoError,
+		},
+		{
+			name: "error with an incorrect ID",
+			args: args{
+				event: Event{ID: "2\n3", Data: "test"},
+			},
+			wantResponse: &http.Response{
+				Status: strconv.Itoa(http.StatusOK) + " " +
+					http.StatusText(http.StatusOK),
+				StatusCode: http.StatusOK,
+				Proto:      "HTTP/1.1",
+				ProtoMajor: 1,
+				ProtoMinor: 1,
+				Header: http.Header{
+					"Content-Type":      {ContentType},
+					"Cache-Control":     {"no-cache"},
+					"X-Accel-Buffering": {"no"},
+				},
+				Body:          ioutil.NopCloser(bytes.NewReader(nil)),
+				ContentLength: -1,
+			},
+			wantErr: assert.Error,
+		},
+		{
+			name: "error with marshalling",
+			args: args{
+				event: Event{Data: func() {}},
+			},
+			wantResponse: &http.Response{
+				Status: strconv.Itoa(http.StatusOK) + " " +
+					http.StatusText(http.StatusOK),
+				StatusCode: http.StatusOK,
+				Proto:      "HTTP/1.1",
+				ProtoMajor: 1,
+				ProtoMinor: 1,
+				Header: http.Header{
+					"Content-Type":      {ContentType},
+					"Cache-Control":     {"no-cache"},
+					"X-Accel-Buffering": {"no"},
+				},
+				Body:          ioutil.NopCloser(bytes.NewReader(nil)),
+				ContentLength: -1,
+			},
+			wantErr: assert.Error,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			responseRecorder := httptest.NewRecorder()
+			request := httptest.NewRequest(http.MethodGet, "http://example.com/test", nil)
+
+			writer, err := NewWriter(responseRecorder, request, &MockLogger{})
+			require.NoError(t, err)
+
+			err = writer.Send(tt.args.event)
+
+			assert.Equal(t, tt.wantResponse, responseRecorder.Result())
+			tt.wantErr(t, err)
+		})
+	}
+}
+
+func TestWriter_LastEventID(t *testing.T) {
+	tests := []struct {
+		name    string
+		request *http.Request
+		want    string
+	}{
+		{
+			name: "from the header",
+			request: func() *http.Request {
+				request := httptest.NewRequest(http.MethodGet, "/test", nil)
+				request.Header.Set(LastEventIDHeader, "23")
+
+				return request
+			}(),
+			want: "23",
+		},
+		{
+			name:    "from the query",
+			request: httptest.NewRequest(http.MethodGet, "/test?lastEventId=42", nil),
+			want:    "42",
+		},
+		{
+			name:    "without the ID",
+			request: httptest.NewRequest(http.MethodGet, "/test", nil),
+			want:    "",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			writer, err := NewWriter(httptest.NewRecorder(), tt.request, &MockLogger{})
+			require.NoError(t, err)
+
+			got := writer.LastEventID()
+
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestWriter_Run(t *testing.T) {
+	t.Run("with a closed channel", func(t *testing.T) {
+		responseRecorder := httptest.NewRecorder()
+		request := httptest.NewRequest(http.MethodGet, "http://example.com/test", nil)
+
+		writer, err := NewWriter(responseRecorder, request, &MockLogger{})
+		require.NoError(t, err)
+
+		events := make(chan Event, 2)
+		events <- Event{ID: "1", Data: 23}
+		events <- Event{ID: "2", Data: 42}
+		close(events)
+
+		err = writer.Run(events, time.Hour)
+
+		assert.NoError(t, err)
+		assert.Equal(
+			t,
+			"id: 1\ndata: 23\n\nid: 2\ndata: 42\n\n",
+			responseRecorder.Body.String(),
+		)
+	})
+
+	t.Run("with a cancelled request", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		responseRecorder := httptest.NewRecorder()
+		request := httptest.NewRequest(http.MethodGet, "http://example.com/test", nil)
+		request = request.WithContext(ctx)
+
+		writer, err := NewWriter(responseRecorder, request, &MockLogger{})
+		require.NoError(t, err)
+
+		err = writer.Run(make(chan Event), 0)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "", responseRecorder.Body.String())
+	})
+
+	t.Run("with an error", func(t *testing.T) {
+		logger := &MockLogger{}
+		logger.InnerMock.
+			On("Print", []interface{}{
+				"GET http://example.com/test: unable to send the event: " +
+					"unable to marshal the data: json: unsupported type: func()",
+			}).
+			Return().
+			Times(1)
+
+		responseRecorder := httptest.NewRecorder()
+		request := httptest.NewRequest(http.MethodGet, "http://example.com/test", nil)
+
+		writer, err := NewWriter(responseRecorder, request, logger)
+		require.NoError(t, err)
+
+		events := make(chan Event, 1)
+		events <- Event{Data: func() {}}
+
+		err = writer.Run(events, 0)
+
+		logger.InnerMock.AssertExpectations(t)
+		assert.Error(t, err)
+	})
+}
+
+func TestWriter_endToEnd(t *testing.T) {
+	type testData struct {
+		Progress int `json:"progress"`
+	}
+
+	handler := middlewares.CORSMiddleware(http.HandlerFunc(func(
+		writer http.ResponseWriter,
+		request *http.Request,
+	) {
+		sseWriter, err := NewWriter(writer, request, &MockLogger{})
+		require.NoError(t, err)
+
+		lastEventID, err := strconv.Atoi(sseWriter.LastEventID())
+		require.NoError(t, err)
+
+		events := make(chan Event, 2)
+		for id := lastEventID + 1; id <= lastEventID+2; id++ {
+			events <- Event{
+				ID:   strconv.Itoa(id),
+				Name: "progress",
+				Data: testData{Progress: id * 10},
+			}
+		}
+		close(events)
+
+		sseWriter.Run(events, 0)
+	}))
+
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	reader, err := Subscribe(http.DefaultClient, server.URL, "", "2")
+	require.NoError(t, err)
+	defer reader.Close()
+
+	var progresses []int
+	for index := 0; index < 2; index++ {
+		event, err := reader.Next()
+		require.NoError(t, err)
+		assert.Equal(t, "progress", event.Name)
+
+		var data testData
+		require.NoError(t, event.DecodeData(&data))
+
+		progresses = append(progresses, data.Progress)
+	}
+
+	assert.Equal(t, []int{30, 40}, progresses)
+	assert.Equal(t, "4", reader.LastEventID())
+}
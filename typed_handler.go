@@ -0,0 +1,173 @@
+package httputils
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strings"
+)
+
+var (
+	contextType = reflect.TypeOf((*context.Context)(nil)).Elem()
+	errorType   = reflect.TypeOf((*error)(nil)).Elem()
+)
+
+// Validator ...
+type Validator interface {
+	Validate() error
+}
+
+// TypedHandler ...
+func TypedHandler(function interface{}, errorWriter ErrorWriter) http.Handler {
+	functionValue := reflect.ValueOf(function)
+	if err := checkTypedHandlerType(functionValue.Type()); err != nil {
+		panic(fmt.Sprintf("unable to make the typed handler: %s", err))
+	}
+
+	requestType := functionValue.Type().In(1)
+	return http.HandlerFunc(func(
+		writer http.ResponseWriter,
+		request *http.Request,
+	) {
+		requestValue, err := decodeTypedRequest(request, requestType)
+		if err != nil {
+			errorWriter.WriteHTTPError(writer, request, err)
+			return
+		}
+
+		results := functionValue.Call([]reflect.Value{
+			reflect.ValueOf(request.Context()),
+			requestValue,
+		})
+		if err, _ := results[1].Interface().(error); err != nil {
+			errorWriter.WriteHTTPError(writer, request, err)
+			return
+		}
+
+		HandleJSON(writer, errorWriter.Logger, results[0].Interface())
+	})
+}
+
+func checkTypedHandlerType(functionType reflect.Type) error {
+	if functionType.Kind() != reflect.Func {
+		return errors.New("handler should be a function")
+	}
+	if functionType.NumIn() != 2 || functionType.NumOut() != 2 {
+		return errors.New("handler should have two parameters and two results")
+	}
+	if functionType.In(0) != contextType {
+		return errors.New("first parameter should be a context")
+	}
+
+	requestType := functionType.In(1)
+	if requestType.Kind() == reflect.Ptr {
+		requestType = requestType.Elem()
+	}
+	if requestType.Kind() != reflect.Struct {
+		return errors.New("second parameter should be a struct or a pointer to it")
+	}
+
+	if functionType.Out(1) != errorType {
+		return errors.New("second result should be an error")
+	}
+
+	return nil
+}
+
+func decodeTypedRequest(
+	request *http.Request,
+	requestType reflect.Type,
+) (reflect.Value, error) {
+	isPointer := requestType.Kind() == reflect.Ptr
+	if isPointer {
+		requestType = requestType.Elem()
+	}
+
+	requestValue := reflect.New(requestType)
+	requestData := requestValue.Interface()
+	var bodyKeys map[string]json.RawMessage
+	if request.Body != nil &&
+		request.Body != http.NoBody &&
+		request.ContentLength != 0 {
+		var err error
+		bodyKeys, err = decodeTypedRequestBody(request, requestData)
+		if err != nil {
+			return reflect.Value{}, fmt.Errorf("unable to decode the request: %w", err)
+		}
+	}
+
+	// fields set by the body, even to zero values,
+	// are neither required nor defaulted by their bind sources
+	isFieldSet := func(field reflect.StructField) bool {
+		return hasJSONKey(bodyKeys, field)
+	}
+	err := bindValues(requestData, makeRequestLookup(request), isFieldSet)
+	if err != nil {
+		return reflect.Value{}, fmt.Errorf("unable to bind the request: %w", err)
+	}
+
+	if validator, ok := requestData.(Validator); ok {
+		if err := validator.Validate(); err != nil {
+			var httpErr *HTTPError
+			var validationErr *ValidationError
+			if !errors.As(err, &httpErr) && !errors.As(err, &validationErr) {
+				err = NewHTTPError(http.StatusBadRequest, err.Error(), nil)
+			}
+
+			return reflect.Value{}, err
+		}
+	}
+
+	if !isPointer {
+		requestValue = requestValue.Elem()
+	}
+
+	return requestValue, nil
+}
+
+func decodeTypedRequestBody(
+	request *http.Request,
+	requestData interface{},
+) (map[string]json.RawMessage, error) {
+	var body json.RawMessage
+	if err := DecodeJSONRequest(request, &body, JSONDecodingOptions{}); err != nil {
+		return nil, err
+	}
+
+	// the body is already limited
+	options := JSONDecodingOptions{MaxBodySize: -1}
+	if err := decodeJSON(bytes.NewReader(body), requestData, options); err != nil {
+		return nil, err
+	}
+
+	// a body that isn't an object has no keys
+	var bodyKeys map[string]json.RawMessage
+	_ = json.Unmarshal(body, &bodyKeys)
+
+	return bodyKeys, nil
+}
+
+// it matches the keys like encoding/json does, i.e. case-insensitively
+func hasJSONKey(keys map[string]json.RawMessage, field reflect.StructField) bool {
+	name := field.Name
+	if tag, ok := field.Tag.Lookup("json"); ok {
+		if tag == "-" {
+			return false
+		}
+		if tagName := strings.Split(tag, ",")[0]; tagName != "" {
+			name = tagName
+		}
+	}
+
+	for key := range keys {
+		if strings.EqualFold(key, name) {
+			return true
+		}
+	}
+
+	return false
+}
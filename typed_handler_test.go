@@ -0,0 +1,331 @@
+package httputils
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type testTypedRequest struct {
+	UserID int    `bind:"source=path,name=userID,required"`
+	Title  string `json:"title"`
+}
+
+func (request testTypedRequest) Validate() error {
+	if request.Title == "" {
+		return errors.New("title is empty")
+	}
+
+	return nil
+}
+
+type testTypedBoundRequest struct {
+	UserID int    `json:"user_id" bind:"source=path,name=userID,required"`
+	Author string `json:"author" bind:"source=query,name=author,default=anon"`
+	Title  string `json:"title" bind:"source=query,name=title,required"`
+}
+
+type testTypedResponse struct {
+	UserID int    `json:"user_id"`
+	Title  string `json:"title"`
+}
+
+func TestTypedHandler(t *testing.T) {
+	type args struct {
+		function interface{}
+		logger   Logger
+		request  *http.Request
+	}
+
+	makeRequest := func(body string) *http.Request {
+		request := httptest.NewRequest(
+			http.MethodPost,
+			"http://example.com/users/23/todos",
+			strings.NewReader(body),
+		)
+		request.Header.Set("Content-Type", "application/json")
+
+		return SetPathParameters(request, PathParameters{"userID": "23"})
+	}
+
+	tests := []struct {
+		name         string
+		args         args
+		wantResponse *http.Response
+	}{
+		{
+			name: "success",
+			args: args{
+				function: func(
+					ctx context.Context,
+					request testTypedRequest,
+				) (testTypedResponse, error) {
+					return testTypedResponse(request), nil
+				},
+				logger:  &MockLogger{},
+				request: makeRequest(`{"title":"test"}`),
+			},
+			wantResponse: &http.Response{
+				Status: strconv.Itoa(http.StatusOK) + " " +
+					http.StatusText(http.StatusOK),
+				StatusCode: http.StatusOK,
+				Proto:      "HTTP/1.1",
+				ProtoMajor: 1,
+				ProtoMinor: 1,
+				Header:     http.Header{"Content-Type": {"application/json"}},
+				Body: ioutil.NopCloser(bytes.NewReader(
+					[]byte(`{"user_id":23,"title":"test"}`),
+				)),
+				ContentLength: -1,
+			},
+		},
+		{
+			name: "success with the body and the bind tags",
+			args: args{
+				function: func(
+					ctx context.Context,
+					request testTypedBoundRequest,
+				) (testTypedBoundRequest, error) {
+					return request, nil
+				},
+				logger:  &MockLogger{},
+				request: makeRequest(`{"author":"bob","title":"test"}`),
+			},
+			wantResponse: &http.Response{
+				Status: strconv.Itoa(http.StatusOK) + " " +
+					http.StatusText(http.StatusOK),
+				StatusCode: http.StatusOK,
+				Proto:      "HTTP/1.1",
+				ProtoMajor: 1,
+				ProtoMinor: 1,
+				Header:     http.Header{"Content-Type": {"application/json"}},
+				Body: ioutil.NopCloser(bytes.NewReader(
+					[]byte(`{"user_id":23,"author":"bob","title":"test"}`),
+				)),
+				ContentLength: -1,
+			},
+		},
+		{
+			name: "success with the body and the bind defaults",
+			args: args{
+				function: func(
+					ctx context.Context,
+					request testTypedBoundRequest,
+				) (testTypedBoundRequest, error) {
+					return request, nil
+				},
+				logger:  &MockLogger{},
+				request: makeRequest(`{"title":"test"}`),
+			},
+			wantResponse: &http.Response{
+				Status: strconv.Itoa(http.StatusOK) + " " +
+					http.StatusText(http.StatusOK),
+				StatusCode: http.StatusOK,
+				Proto:      "HTTP/1.1",
+				ProtoMajor: 1,
+				ProtoMinor: 1,
+				Header:     http.Header{"Content-Type": {"application/json"}},
+				Body: ioutil.NopCloser(bytes.NewReader(
+					[]byte(`{"user_id":23,"author":"anon","title":"test"}`),
+				)),
+				ContentLength: -1,
+			},
+		},
+		{
+			name: "success with the explicit zero value in the body",
+			args: args{
+				function: func(
+					ctx context.Context,
+					request testTypedBoundRequest,
+				) (testTypedBoundRequest, error) {
+					return request, nil
+				},
+				logger:  &MockLogger{},
+				request: makeRequest(`{"Author":"","title":"test"}`),
+			},
+			wantResponse: &http.Response{
+				Status: strconv.Itoa(http.StatusOK) + " " +
+					http.StatusText(http.StatusOK),
+				StatusCode: http.StatusOK,
+				Proto:      "HTTP/1.1",
+				ProtoMajor: 1,
+				ProtoMinor: 1,
+				Header:     http.Header{"Content-Type": {"application/json"}},
+				Body: ioutil.NopCloser(bytes.NewReader(
+					[]byte(`{"user_id":23,"author":"","title":"test"}`),
+				)),
+				ContentLength: -1,
+			},
+		},
+		{
+			name: "error with decoding",
+			args: args{
+				function: func(
+					ctx context.Context,
+					request *testTypedRequest,
+				) (testTypedResponse, error) {
+					return testTypedResponse(*request), nil
+				},
+				logger: func() Logger {
+					logger := &MockLogger{}
+					logger.InnerMock.
+						On("Print", []interface{}{
+							"POST http://example.com/users/23/todos: " +
+								"400 unable to decode the request: " +
+								"invalid character 'i' looking for beginning of value " +
+								"(offset 1, line 1, column 2)",
+						}).
+						Return().
+						Times(1)
+
+					return logger
+				}(),
+				request: makeRequest("incorrect"),
+			},
+			wantResponse: &http.Response{
+				Status: strconv.Itoa(http.StatusBadRequest) + " " +
+					http.StatusText(http.StatusBadRequest),
+				StatusCode: http.StatusBadRequest,
+				Proto:      "HTTP/1.1",
+				ProtoMajor: 1,
+				ProtoMinor: 1,
+				Header:     http.Header{},
+				Body: ioutil.NopCloser(bytes.NewReader([]byte(
+					"unable to decode the request: " +
+						"invalid character 'i' looking for beginning of value " +
+						"(offset 1, line 1, column 2)",
+				))),
+				ContentLength: -1,
+			},
+		},
+		{
+			name: "error with validation",
+			args: args{
+				function: func(
+					ctx context.Context,
+					request testTypedRequest,
+				) (testTypedResponse, error) {
+					return testTypedResponse(request), nil
+				},
+				logger: func() Logger {
+					logger := &MockLogger{}
+					logger.InnerMock.
+						On("Print", []interface{}{
+							"POST http://example.com/users/23/todos: 400 title is empty",
+						}).
+						Return().
+						Times(1)
+
+					return logger
+				}(),
+				request: makeRequest(`{}`),
+			},
+			wantResponse: &http.Response{
+				Status: strconv.Itoa(http.StatusBadRequest) + " " +
+					http.StatusText(http.StatusBadRequest),
+				StatusCode:    http.StatusBadRequest,
+				Proto:         "HTTP/1.1",
+				ProtoMajor:    1,
+				ProtoMinor:    1,
+				Header:        http.Header{},
+				Body:          ioutil.NopCloser(bytes.NewReader([]byte("title is empty"))),
+				ContentLength: -1,
+			},
+		},
+		{
+			name: "error with the handler",
+			args: args{
+				function: func(
+					ctx context.Context,
+					request testTypedRequest,
+				) (testTypedResponse, error) {
+					return testTypedResponse{},
+						NewHTTPError(http.StatusConflict, "todo already exists", nil)
+				},
+				logger: func() Logger {
+					logger := &MockLogger{}
+					logger.InnerMock.
+						On("Print", []interface{}{
+							"POST http://example.com/users/23/todos: 409 todo already exists",
+						}).
+						Return().
+						Times(1)
+
+					return logger
+				}(),
+				request: makeRequest(`{"title":"test"}`),
+			},
+			wantResponse: &http.Response{
+				Status: strconv.Itoa(http.StatusConflict) + " " +
+					http.StatusText(http.StatusConflict),
+				StatusCode: http.StatusConflict,
+				Proto:      "HTTP/1.1",
+				ProtoMajor: 1,
+				ProtoMinor: 1,
+				Header:     http.Header{},
+				Body: ioutil.NopCloser(bytes.NewReader(
+					[]byte("todo already exists"),
+				)),
+				ContentLength: -1,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			responseRecorder := httptest.NewRecorder()
+			handler := TypedHandler(tt.args.function, ErrorWriter{Logger: tt.args.logger})
+			handler.ServeHTTP(responseRecorder, tt.args.request)
+
+			tt.args.logger.(*MockLogger).InnerMock.AssertExpectations(t)
+			assert.Equal(t, tt.wantResponse, responseRecorder.Result())
+		})
+	}
+}
+
+func TestTypedHandler_withIncorrectFunction(t *testing.T) {
+	tests := []struct {
+		name     string
+		function interface{}
+	}{
+		{
+			name:     "with a non-function",
+			function: "incorrect",
+		},
+		{
+			name: "without a context",
+			function: func(request testTypedRequest) (testTypedResponse, error) {
+				return testTypedResponse{}, nil
+			},
+		},
+		{
+			name: "with a non-struct request",
+			function: func(ctx context.Context, request int) (testTypedResponse, error) {
+				return testTypedResponse{}, nil
+			},
+		},
+		{
+			name: "without an error",
+			function: func(
+				ctx context.Context,
+				request testTypedRequest,
+			) (testTypedResponse, string) {
+				return testTypedResponse{}, ""
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Panics(t, func() {
+				TypedHandler(tt.function, ErrorWriter{Logger: &MockLogger{}})
+			})
+		})
+	}
+}
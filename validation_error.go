@@ -0,0 +1,135 @@
+package httputils
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// ValidationErrorCode ...
+type ValidationErrorCode string
+
+// ...
+const (
+	ValidationErrorCodeMissing    ValidationErrorCode = "missing"
+	ValidationErrorCodeOutOfRange ValidationErrorCode = "out_of_range"
+	ValidationErrorCodeBadFormat  ValidationErrorCode = "bad_format"
+)
+
+// FieldError ...
+type FieldError struct {
+	Field   string              `json:"field"`
+	Code    ValidationErrorCode `json:"code"`
+	Message string              `json:"message"`
+	Err     error               `json:"-"`
+}
+
+// Error ...
+func (err FieldError) Error() string {
+	return fmt.Sprintf("%s: %s", err.Field, err.Err)
+}
+
+// Unwrap ...
+func (err FieldError) Unwrap() error {
+	return err.Err
+}
+
+// ValidationError ...
+type ValidationError struct {
+	Fields []FieldError `json:"errors"`
+}
+
+// Add ...
+func (err *ValidationError) Add(
+	field string,
+	code ValidationErrorCode,
+	message string,
+) {
+	err.Fields = append(err.Fields, FieldError{
+		Field:   field,
+		Code:    code,
+		Message: message,
+		Err:     errors.New(message),
+	})
+}
+
+// AddError ...
+func (err *ValidationError) AddError(field string, fieldErr error) {
+	if fieldErr == nil {
+		return
+	}
+
+	var code ValidationErrorCode
+	switch {
+	case errors.Is(fieldErr, ErrKeyIsMissed),
+		errors.Is(fieldErr, ErrParameterIsMissed):
+		code = ValidationErrorCodeMissing
+	case errors.Is(fieldErr, ErrValueTooLess),
+		errors.Is(fieldErr, ErrValueTooGreater):
+		code = ValidationErrorCodeOutOfRange
+	default:
+		code = ValidationErrorCodeBadFormat
+	}
+
+	err.Fields = append(err.Fields, FieldError{
+		Field:   field,
+		Code:    code,
+		Message: fieldErr.Error(),
+		Err:     fieldErr,
+	})
+}
+
+// ErrorOrNil ...
+func (err *ValidationError) ErrorOrNil() error {
+	if len(err.Fields) == 0 {
+		return nil
+	}
+
+	return err
+}
+
+// Error ...
+func (err *ValidationError) Error() string {
+	messages := make([]string, 0, len(err.Fields))
+	for _, field := range err.Fields {
+		messages = append(messages, field.Error())
+	}
+
+	return "validation was failed: " + strings.Join(messages, "; ")
+}
+
+// Is ...
+func (err *ValidationError) Is(target error) bool {
+	for _, field := range err.Fields {
+		if errors.Is(field, target) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// HandleValidationError ...
+func HandleValidationError(
+	writer http.ResponseWriter,
+	logger Logger,
+	err *ValidationError,
+) {
+	errBytes, marshallingErr := json.Marshal(err)
+	if marshallingErr != nil {
+		status, message := http.StatusInternalServerError,
+			"unable to marshal the validation error"
+		ErrorWriter{Logger: logger}.
+			WriteError(writer, nil, status, message, marshallingErr)
+
+		return
+	}
+
+	logger.Print(err.Error())
+
+	writer.Header().Set("Content-Type", "application/json")
+	writer.WriteHeader(http.StatusBadRequest)
+	writer.Write(errBytes)
+}
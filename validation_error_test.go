@@ -0,0 +1,139 @@
+package httputils
+
+import (
+	"bytes"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidationError_AddError(t *testing.T) {
+	request := httptest.NewRequest(
+		http.MethodGet,
+		"/test?limit=1000&offset=value&day=2006-01-02",
+		nil,
+	)
+
+	var validationErr ValidationError
+	_, err := GetIntFormValue(request, "limit", 1, 100)
+	validationErr.AddError("limit", err)
+	_, err = GetIntFormValue(request, "offset", 0, 100)
+	validationErr.AddError("offset", err)
+	_, err = GetIntFormValue(request, "page", 0, 100)
+	validationErr.AddError("page", err)
+	_, err = GetDateFormValue(request, "day")
+	validationErr.AddError("day", err)
+
+	var codes []ValidationErrorCode
+	for _, field := range validationErr.Fields {
+		codes = append(codes, field.Code)
+	}
+
+	wantCodes := []ValidationErrorCode{
+		ValidationErrorCodeOutOfRange,
+		ValidationErrorCodeBadFormat,
+		ValidationErrorCodeMissing,
+	}
+	assert.Equal(t, wantCodes, codes)
+	assert.True(t, errors.Is(validationErr.ErrorOrNil(), ErrKeyIsMissed))
+	assert.True(t, errors.Is(validationErr.ErrorOrNil(), ErrValueTooGreater))
+}
+
+func TestValidationError_ErrorOrNil(t *testing.T) {
+	tests := []struct {
+		name          string
+		validationErr *ValidationError
+		wantErr       assert.ErrorAssertionFunc
+	}{
+		{
+			name:          "without fields",
+			validationErr: &ValidationError{},
+			wantErr:       assert.NoError,
+		},
+		{
+			name: "with fields",
+			validationErr: func() *ValidationError {
+				validationErr := &ValidationError{}
+				validationErr.Add("limit", ValidationErrorCodeMissing, "key is missed")
+
+				return validationErr
+			}(),
+			wantErr: assert.Error,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.validationErr.ErrorOrNil()
+
+			tt.wantErr(t, err)
+		})
+	}
+}
+
+func TestHandleValidationError(t *testing.T) {
+	type args struct {
+		logger Logger
+		err    *ValidationError
+	}
+
+	tests := []struct {
+		name         string
+		args         args
+		wantResponse *http.Response
+	}{
+		{
+			name: "success",
+			args: args{
+				logger: func() Logger {
+					logger := &MockLogger{}
+					logger.InnerMock.
+						On("Print", []interface{}{
+							"validation was failed: limit: key is missed; " +
+								"offset: value too less",
+						}).
+						Return().
+						Times(1)
+
+					return logger
+				}(),
+				err: func() *ValidationError {
+					validationErr := &ValidationError{}
+					validationErr.AddError("limit", ErrKeyIsMissed)
+					validationErr.AddError("offset", ErrValueTooLess)
+
+					return validationErr
+				}(),
+			},
+			wantResponse: &http.Response{
+				Status: strconv.Itoa(http.StatusBadRequest) + " " +
+					http.StatusText(http.StatusBadRequest),
+				StatusCode: http.StatusBadRequest,
+				Proto:      "HTTP/1.1",
+				ProtoMajor: 1,
+				ProtoMinor: 1,
+				Header:     http.Header{"Content-Type": {"application/json"}},
+				Body: ioutil.NopCloser(bytes.NewReader([]byte(
+					`{"errors":[` +
+						`{"field":"limit","code":"missing","message":"key is missed"},` +
+						`{"field":"offset","code":"out_of_range","message":"value too less"}` +
+						`]}`,
+				))),
+				ContentLength: -1,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			responseRecorder := httptest.NewRecorder()
+			HandleValidationError(responseRecorder, tt.args.logger, tt.args.err)
+
+			tt.args.logger.(*MockLogger).InnerMock.AssertExpectations(t)
+			assert.Equal(t, tt.wantResponse, responseRecorder.Result())
+		})
+	}
+}